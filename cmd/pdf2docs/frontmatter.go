@@ -0,0 +1,50 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "github.com/spf13/cobra"
+    "github.com/thywilljoshua/pdf-to-docs/internal/convert"
+    "github.com/thywilljoshua/pdf-to-docs/internal/frontmatter"
+)
+
+func convertFrontmatterCmd() *cobra.Command {
+    var out string
+    var to string
+
+    cmd := &cobra.Command{
+        Use:   "convert-frontmatter",
+        Short: "Rewrite an already-generated docs tree's front matter into yaml, toml, or json",
+        RunE: func(cmd *cobra.Command, args []string) error {
+            format, err := parseFrontMatterFormat(to)
+            if err != nil {
+                return err
+            }
+            res, err := convert.ConvertFrontMatter(cmd.Context(), convert.FrontMatterConfig{OutDir: out, To: format})
+            if err != nil {
+                return err
+            }
+            b, _ := json.MarshalIndent(res, "", "  ")
+            fmt.Fprintln(cmd.OutOrStdout(), string(b))
+            return nil
+        },
+    }
+    cmd.Flags().StringVarP(&out, "out", "o", ".", "docs directory to rewrite front matter under")
+    cmd.Flags().StringVar(&to, "to", "yaml", "target front matter format: yaml|toml|json")
+    return cmd
+}
+
+func parseFrontMatterFormat(s string) (frontmatter.Format, error) {
+    switch strings.ToLower(s) {
+    case "yaml", "yml":
+        return frontmatter.YAML, nil
+    case "toml":
+        return frontmatter.TOML, nil
+    case "json":
+        return frontmatter.JSON, nil
+    default:
+        return frontmatter.None, fmt.Errorf("unknown --to format %q: want yaml, toml, or json", s)
+    }
+}
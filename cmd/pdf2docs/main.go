@@ -14,6 +14,8 @@ func main() {
     }
 
     root.AddCommand(convertCmd())
+    root.AddCommand(watchCmd())
+    root.AddCommand(convertFrontmatterCmd())
 
     if err := root.Execute(); err != nil {
         fmt.Fprintln(os.Stderr, err)
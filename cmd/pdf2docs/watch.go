@@ -0,0 +1,57 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "time"
+
+    "github.com/spf13/cobra"
+    "github.com/thywilljoshua/pdf-to-docs/internal/convert"
+)
+
+func watchCmd() *cobra.Command {
+    f := &convertFlags{}
+    var interval time.Duration
+
+    cmd := &cobra.Command{
+        Use:   "watch <pdf>",
+        Short: "Poll a PDF for changes and incrementally re-run convert on change",
+        Args:  cobra.ExactArgs(1),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            pdfPath := args[0]
+            var lastMod time.Time
+            var lastSize int64
+
+            rebuild := func() error {
+                res, err := convert.Run(cmd.Context(), pdfPath, buildConfig(f))
+                if err != nil {
+                    return err
+                }
+                fmt.Fprintf(cmd.OutOrStdout(), "rebuilt: %d written, %d unchanged, %d images\n", res.Written, res.Unchanged, res.Images)
+                return nil
+            }
+
+            for {
+                fi, err := os.Stat(pdfPath)
+                if err != nil {
+                    return err
+                }
+                if fi.ModTime() != lastMod || fi.Size() != lastSize {
+                    lastMod, lastSize = fi.ModTime(), fi.Size()
+                    if err := rebuild(); err != nil {
+                        fmt.Fprintln(cmd.ErrOrStderr(), err)
+                    }
+                }
+
+                select {
+                case <-time.After(interval):
+                case <-cmd.Context().Done():
+                    return cmd.Context().Err()
+                }
+            }
+        },
+    }
+    addConvertFlags(cmd, f)
+    cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "how often to poll the source PDF for changes")
+    return cmd
+}
@@ -16,17 +16,32 @@ type StructuredDoc struct {
     Sections []StructuredSection `json:"sections"`
 }
 
+// RepairedToC is RepairToC's result: the cleaned lines plus, if the
+// enhancer normalized them to a particular numbering convention, which
+// TOCScheme name that convention corresponds to, so convert's depth
+// assignment stays consistent with what was actually produced.
+type RepairedToC struct {
+    Lines      []string
+    SchemeHint string
+}
+
 type Enhancer interface {
-    RepairToC(ctx context.Context, raw []string) ([]string, error)
+    RepairToC(ctx context.Context, raw []string) (RepairedToC, error)
     Summarize(ctx context.Context, text string, maxTokens int) (string, error)
     SuggestComponents(ctx context.Context, text string, allow []string, mode string) (string, error)
     Caption(ctx context.Context, imagePath string) (string, error)
     ExtractStructure(ctx context.Context, pdfPath string, maxDepth, tocPages int) (StructuredDoc, error)
+    // ModelID identifies the underlying model/provider, so callers that
+    // fingerprint on Enhancer configuration (convert's incremental content
+    // map) can tell one model apart from another instead of just "AI on".
+    ModelID() string
 }
 
 type Noop struct{}
 
-func (Noop) RepairToC(ctx context.Context, raw []string) ([]string, error) { return raw, nil }
+func (Noop) RepairToC(ctx context.Context, raw []string) (RepairedToC, error) {
+    return RepairedToC{Lines: raw}, nil
+}
 func (Noop) Summarize(ctx context.Context, text string, maxTokens int) (string, error) {
     return "", nil
 }
@@ -37,4 +52,5 @@ func (Noop) Caption(ctx context.Context, imagePath string) (string, error) { ret
 func (Noop) ExtractStructure(ctx context.Context, pdfPath string, maxDepth, tocPages int) (StructuredDoc, error) {
     return StructuredDoc{}, nil
 }
+func (Noop) ModelID() string { return "noop" }
 
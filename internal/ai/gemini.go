@@ -34,6 +34,9 @@ func NewGemini(ctx context.Context, apiKey, model string) (*Gemini, error) {
     return &Gemini{client: c, model: model, ComponentsMode: "conservative"}, nil
 }
 
+// ModelID returns the Gemini model name this instance was constructed with.
+func (g *Gemini) ModelID() string { return g.model }
+
 func (g *Gemini) prompt(ctx context.Context, text string) (string, error) {
     res, err := g.client.Models.GenerateContent(ctx, g.model, []*genai.Content{
         genai.NewContentFromText(text, genai.RoleUser),
@@ -44,16 +47,19 @@ func (g *Gemini) prompt(ctx context.Context, text string) (string, error) {
     return res.Text(), nil
 }
 
-func (g *Gemini) RepairToC(ctx context.Context, raw []string) ([]string, error) {
+// RepairToC asks the model to normalize raw into one "NUMBER TITLE ....
+// PAGE" entry per line, which is the numeric TOCScheme's format, so a
+// successful repair always hints "numeric" downstream.
+func (g *Gemini) RepairToC(ctx context.Context, raw []string) (RepairedToC, error) {
     if g.client == nil {
-        return raw, nil
+        return RepairedToC{Lines: raw}, nil
     }
     joined := "Fix and normalize this Table of Contents to one entry per line as 'NUMBER TITLE .... PAGE', keep order, no extra text.\n\n" + joinLines(raw)
     out, err := g.prompt(ctx, joined)
     if err != nil || out == "" {
-        return raw, nil
+        return RepairedToC{Lines: raw}, nil
     }
-    return splitLines(out), nil
+    return RepairedToC{Lines: splitLines(out), SchemeHint: "numeric"}, nil
 }
 
 func (g *Gemini) Summarize(ctx context.Context, text string, maxTokens int) (string, error) {
@@ -0,0 +1,181 @@
+package ai
+
+import (
+    "context"
+    "errors"
+    "math"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// errCircuitOpen is returned in place of calling next when the circuit
+// breaker has tripped and its cooldown hasn't elapsed yet.
+var errCircuitOpen = errors.New("ai: circuit breaker open, skipping call")
+
+// retryCountKey is the context key WithRetryCount stashes its counter under.
+type retryCountKey struct{}
+
+// WithRetryCount returns a context that accumulates the number of retries
+// any resilient Enhancer call made with it needed into counter. Callers use
+// this to report per-call retry counts (convert.Run's per-section timing,
+// for instance) without threading a counter through every method signature.
+func WithRetryCount(ctx context.Context, counter *atomic.Int32) context.Context {
+    return context.WithValue(ctx, retryCountKey{}, counter)
+}
+
+func recordRetries(ctx context.Context, n int) {
+    if n == 0 {
+        return
+    }
+    if c, ok := ctx.Value(retryCountKey{}).(*atomic.Int32); ok {
+        c.Add(int32(n))
+    }
+}
+
+// circuitBreaker opens after threshold consecutive failures and stays open
+// for cooldown before allowing another trial call through.
+type circuitBreaker struct {
+    mu        sync.Mutex
+    threshold int
+    cooldown  time.Duration
+    failures  int
+    openUntil time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+    if threshold <= 0 {
+        threshold = 5
+    }
+    if cooldown <= 0 {
+        cooldown = 30 * time.Second
+    }
+    return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+func (b *circuitBreaker) allow() bool {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    return b.openUntil.IsZero() || time.Now().After(b.openUntil)
+}
+
+func (b *circuitBreaker) recordResult(err error) {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    if err == nil {
+        b.failures = 0
+        b.openUntil = time.Time{}
+        return
+    }
+    b.failures++
+    if b.failures >= b.threshold {
+        b.openUntil = time.Now().Add(b.cooldown)
+    }
+}
+
+// resilient wraps an Enhancer with exponential-backoff retries and a
+// circuit breaker, so one failing or rate-limited model call can't stall or
+// poison an entire run.
+type resilient struct {
+    next       Enhancer
+    maxRetries int
+    baseDelay  time.Duration
+    breaker    *circuitBreaker
+}
+
+// WithResilience wraps next so each call is retried up to maxRetries times
+// with exponentially increasing delay (baseDelay, 2*baseDelay, 4*baseDelay,
+// ...) and short-circuited by a circuit breaker once failures pile up.
+func WithResilience(next Enhancer, maxRetries int, baseDelay time.Duration) Enhancer {
+    if maxRetries < 0 {
+        maxRetries = 0
+    }
+    if baseDelay <= 0 {
+        baseDelay = 250 * time.Millisecond
+    }
+    return &resilient{next: next, maxRetries: maxRetries, baseDelay: baseDelay, breaker: newCircuitBreaker(5, 30*time.Second)}
+}
+
+// withRetry runs call, retrying on error per r's backoff schedule, and
+// reports the retry count and final circuit-breaker state.
+func (r *resilient) withRetry(ctx context.Context, call func() error) error {
+    if !r.breaker.allow() {
+        return errCircuitOpen
+    }
+    var err error
+    retries := 0
+    for attempt := 0; attempt <= r.maxRetries; attempt++ {
+        err = call()
+        if err == nil {
+            break
+        }
+        retries = attempt + 1
+        if attempt == r.maxRetries {
+            break
+        }
+        delay := time.Duration(float64(r.baseDelay) * math.Pow(2, float64(attempt)))
+        select {
+        case <-time.After(delay):
+        case <-ctx.Done():
+            err = ctx.Err()
+            attempt = r.maxRetries // stop retrying, fall through to recordResult below
+        }
+    }
+    r.breaker.recordResult(err)
+    recordRetries(ctx, retries)
+    return err
+}
+
+func (r *resilient) RepairToC(ctx context.Context, raw []string) (RepairedToC, error) {
+    var out RepairedToC
+    err := r.withRetry(ctx, func() error {
+        var callErr error
+        out, callErr = r.next.RepairToC(ctx, raw)
+        return callErr
+    })
+    return out, err
+}
+
+func (r *resilient) Summarize(ctx context.Context, text string, maxTokens int) (string, error) {
+    var out string
+    err := r.withRetry(ctx, func() error {
+        var callErr error
+        out, callErr = r.next.Summarize(ctx, text, maxTokens)
+        return callErr
+    })
+    return out, err
+}
+
+func (r *resilient) SuggestComponents(ctx context.Context, text string, allow []string, mode string) (string, error) {
+    var out string
+    err := r.withRetry(ctx, func() error {
+        var callErr error
+        out, callErr = r.next.SuggestComponents(ctx, text, allow, mode)
+        return callErr
+    })
+    return out, err
+}
+
+func (r *resilient) Caption(ctx context.Context, imagePath string) (string, error) {
+    var out string
+    err := r.withRetry(ctx, func() error {
+        var callErr error
+        out, callErr = r.next.Caption(ctx, imagePath)
+        return callErr
+    })
+    return out, err
+}
+
+// ModelID forwards to the wrapped Enhancer: retries/circuit-breaking don't
+// change which model is being called.
+func (r *resilient) ModelID() string { return r.next.ModelID() }
+
+func (r *resilient) ExtractStructure(ctx context.Context, pdfPath string, maxDepth, tocPages int) (StructuredDoc, error) {
+    var out StructuredDoc
+    err := r.withRetry(ctx, func() error {
+        var callErr error
+        out, callErr = r.next.ExtractStructure(ctx, pdfPath, maxDepth, tocPages)
+        return callErr
+    })
+    return out, err
+}
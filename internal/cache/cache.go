@@ -0,0 +1,287 @@
+// Package cache provides a cross-cutting, memory-bounded cache for
+// ai.Enhancer calls so repeated pdf2docs convert runs against the same PDF
+// don't re-bill tokens or re-pay network latency.
+package cache
+
+import (
+    "container/list"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "os"
+    "path/filepath"
+    "sync"
+)
+
+// Stats tracks cache activity across a single convert.Run invocation.
+type Stats struct {
+    Hits      int `json:"hits"`
+    Misses    int `json:"misses"`
+    Evictions int `json:"evictions"`
+}
+
+// cost weights used to bias eviction: responses that are expensive to
+// regenerate (captions, structured extraction) are harder to evict than a
+// one-line summary.
+const (
+    CostSummary   = 1
+    CostComponent = 2
+    CostCaption   = 4
+    CostStructure = 8
+)
+
+type entry struct {
+    key  string
+    data []byte
+    size int64
+    cost int
+}
+
+// Cache is a two-tier store: an in-memory LRU bounded by total bytes, plus an
+// on-disk directory that survives across process runs.
+type Cache struct {
+    mu       sync.Mutex
+    dir      string
+    memLimit int64
+    memUsed  int64
+    order    *list.List // front = most recently used
+    elems    map[string]*list.Element
+    stats    Stats
+    manifest map[string]manifestEntry // key -> disk entry size/cost, persisted to manifest.json
+}
+
+// manifestEntry records one on-disk cache entry's size and eviction cost, so
+// a disk-tier hit can promote it into memory under its original cost
+// instead of always treating it as the cheapest (CostSummary) tier.
+type manifestEntry struct {
+    Size int64 `json:"size"`
+    Cost int   `json:"cost"`
+}
+
+// manifestName is the file recording each on-disk entry's size and cost,
+// relative to a Cache's dir.
+const manifestName = "manifest.json"
+
+// New creates a Cache rooted at dir (created if missing) with an in-memory
+// budget of memLimitBytes. If dir is empty, the disk tier is disabled and the
+// cache is memory-only for the lifetime of the process.
+func New(dir string, memLimitBytes int64) (*Cache, error) {
+    manifest := make(map[string]manifestEntry)
+    if dir != "" {
+        if err := os.MkdirAll(dir, 0o755); err != nil {
+            return nil, err
+        }
+        if b, err := os.ReadFile(filepath.Join(dir, manifestName)); err == nil {
+            _ = json.Unmarshal(b, &manifest)
+        }
+    }
+    if memLimitBytes <= 0 {
+        memLimitBytes = DefaultMemLimit()
+    }
+    return &Cache{
+        dir:      dir,
+        memLimit: memLimitBytes,
+        order:    list.New(),
+        elems:    make(map[string]*list.Element),
+        manifest: manifest,
+    }, nil
+}
+
+// DefaultMemLimit returns 1/4 of detected system RAM in bytes, or 256MB if
+// detection fails, overridable by the caller via PDF2DOCS_MEMORYLIMIT.
+func DefaultMemLimit() int64 {
+    if v := os.Getenv("PDF2DOCS_MEMORYLIMIT"); v != "" {
+        if gb := parseGB(v); gb > 0 {
+            return int64(gb * (1 << 30))
+        }
+    }
+    total := systemMemoryBytes()
+    if total <= 0 {
+        return 256 << 20
+    }
+    return total / 4
+}
+
+// Fingerprint returns the hex-encoded SHA-256 digest of the concatenated
+// parts, used as the cache key for a given enhancer call.
+func Fingerprint(parts ...[]byte) string {
+    h := sha256.New()
+    for _, p := range parts {
+        h.Write(p)
+        h.Write([]byte{0})
+    }
+    return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get looks up key, checking the in-memory tier first and falling back to
+// disk. A disk hit is promoted into memory.
+func (c *Cache) Get(key string, out any) bool {
+    c.mu.Lock()
+    if el, ok := c.elems[key]; ok {
+        c.order.MoveToFront(el)
+        e := el.Value.(*entry)
+        c.stats.Hits++
+        c.mu.Unlock()
+        return json.Unmarshal(e.data, out) == nil
+    }
+    c.mu.Unlock()
+
+    if c.dir == "" {
+        c.mu.Lock()
+        c.stats.Misses++
+        c.mu.Unlock()
+        return false
+    }
+    b, err := os.ReadFile(c.diskPath(key))
+    if err != nil {
+        c.mu.Lock()
+        c.stats.Misses++
+        c.mu.Unlock()
+        return false
+    }
+    c.mu.Lock()
+    c.stats.Hits++
+    cost := c.manifest[key].Cost
+    c.mu.Unlock()
+    if json.Unmarshal(b, out) != nil {
+        return false
+    }
+    c.put(key, b, cost)
+    return true
+}
+
+// Set stores value under key in both tiers with the given eviction cost.
+func (c *Cache) Set(key string, value any, cost int) {
+    b, err := json.Marshal(value)
+    if err != nil {
+        return
+    }
+    c.mu.Lock()
+    c.stats.Misses++
+    c.mu.Unlock()
+    c.put(key, b, cost)
+    if c.dir != "" {
+        c.writeDisk(key, b, cost)
+    }
+}
+
+// GetOrCreate returns the cached value for key, calling create and storing
+// its result under cost on a miss. T must be JSON-marshalable.
+func GetOrCreate[T any](c *Cache, key string, cost int, create func() (T, error)) (T, error) {
+    var out T
+    if c.Get(key, &out) {
+        return out, nil
+    }
+    out, err := create()
+    if err != nil {
+        return out, err
+    }
+    c.Set(key, out, cost)
+    return out, nil
+}
+
+func (c *Cache) diskPath(key string) string {
+    return filepath.Join(c.dir, key+".json")
+}
+
+// writeDisk persists data under key via write-then-rename so a process
+// killed mid-write never leaves a half-written entry behind, then records
+// the entry's size and cost in the on-disk manifest.
+func (c *Cache) writeDisk(key string, data []byte, cost int) {
+    path := c.diskPath(key)
+    tmp := path + ".tmp"
+    if err := os.WriteFile(tmp, data, 0o644); err != nil {
+        return
+    }
+    if err := os.Rename(tmp, path); err != nil {
+        os.Remove(tmp)
+        return
+    }
+    c.updateManifest(key, int64(len(data)), cost)
+}
+
+// updateManifest records key's on-disk size and cost and persists the
+// manifest via the same write-then-rename pattern as a cache entry itself.
+func (c *Cache) updateManifest(key string, size int64, cost int) {
+    c.mu.Lock()
+    c.manifest[key] = manifestEntry{Size: size, Cost: cost}
+    b, err := json.Marshal(c.manifest)
+    c.mu.Unlock()
+    if err != nil {
+        return
+    }
+
+    path := filepath.Join(c.dir, manifestName)
+    tmp := path + ".tmp"
+    if os.WriteFile(tmp, b, 0o644) != nil {
+        return
+    }
+    if os.Rename(tmp, path) != nil {
+        os.Remove(tmp)
+    }
+}
+
+func (c *Cache) put(key string, data []byte, cost int) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if el, ok := c.elems[key]; ok {
+        e := el.Value.(*entry)
+        c.memUsed -= e.size
+        e.data = data
+        e.size = int64(len(data))
+        e.cost = cost
+        c.memUsed += e.size
+        c.order.MoveToFront(el)
+    } else {
+        e := &entry{key: key, data: data, size: int64(len(data)), cost: cost}
+        c.elems[key] = c.order.PushFront(e)
+        c.memUsed += e.size
+    }
+
+    for c.memUsed > c.memLimit && c.order.Len() > 0 {
+        victim := c.pickVictim()
+        if victim == nil {
+            break
+        }
+        c.order.Remove(victim)
+        e := victim.Value.(*entry)
+        delete(c.elems, e.key)
+        c.memUsed -= e.size
+        c.stats.Evictions++
+    }
+}
+
+// pickVictim scans from the back (least recently used) but skips over
+// high-cost entries while a cheaper one is available nearby, so captions and
+// structured extraction survive longer than throwaway summaries.
+func (c *Cache) pickVictim() *list.Element {
+    const lookback = 8
+    var best *list.Element
+    bestScore := -1
+    el := c.order.Back()
+    for i := 0; el != nil && i < lookback; i, el = i+1, el.Prev() {
+        e := el.Value.(*entry)
+        score := i - e.cost
+        if best == nil || score > bestScore {
+            best, bestScore = el, score
+        }
+    }
+    return best
+}
+
+// Clear empties the in-memory tier; the on-disk tier is left intact.
+func (c *Cache) Clear() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.order.Init()
+    c.elems = make(map[string]*list.Element)
+    c.memUsed = 0
+}
+
+// Stats returns a snapshot of hit/miss/eviction counters.
+func (c *Cache) Stats() Stats {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.stats
+}
@@ -0,0 +1,174 @@
+package cache
+
+import (
+    "errors"
+    "sync"
+    "testing"
+)
+
+func TestGetSetRoundTrip(t *testing.T) {
+    c, err := New("", 1<<20)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    c.Set("k", "v", CostSummary)
+
+    var out string
+    if !c.Get("k", &out) {
+        t.Fatal("Get() = false, want true after Set")
+    }
+    if out != "v" {
+        t.Fatalf("Get() = %q, want %q", out, "v")
+    }
+}
+
+func TestGetMiss(t *testing.T) {
+    c, err := New("", 1<<20)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    var out string
+    if c.Get("missing", &out) {
+        t.Fatal("Get() = true for an unset key, want false")
+    }
+    if got := c.Stats(); got.Misses != 1 {
+        t.Fatalf("Stats().Misses = %d, want 1", got.Misses)
+    }
+}
+
+func TestSetPersistsToDisk(t *testing.T) {
+    c, err := New(t.TempDir(), 1<<20)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    c.Set("k", "v", CostSummary)
+
+    // A fresh Cache over the same dir should see the entry promoted from disk.
+    reopened, err := New(c.dir, 1<<20)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    var out string
+    if !reopened.Get("k", &out) || out != "v" {
+        t.Fatalf("Get() after reopen = %q, %v, want %q, true", out, err, "v")
+    }
+}
+
+// TestDiskPromotionKeepsOriginalCost ensures a disk-tier hit from a reopened
+// Cache promotes its entry under its original cost (from the manifest),
+// not always the cheapest CostSummary, so high-cost entries stay protected
+// from eviction across a process restart.
+func TestDiskPromotionKeepsOriginalCost(t *testing.T) {
+    dir := t.TempDir()
+    c, err := New(dir, 1<<20)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    c.Set("expensive", "b", CostStructure)
+
+    reopened, err := New(dir, 1<<20)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    var out string
+    if !reopened.Get("expensive", &out) {
+        t.Fatal("Get() after reopen = false, want the disk entry promoted into memory")
+    }
+
+    el, ok := reopened.elems["expensive"]
+    if !ok {
+        t.Fatal("promoted entry missing from the in-memory tier")
+    }
+    if got := el.Value.(*entry).cost; got != CostStructure {
+        t.Fatalf("promoted entry cost = %d, want %d (its original Set cost, not CostSummary)", got, CostStructure)
+    }
+}
+
+func TestEvictionPrefersLowCostEntries(t *testing.T) {
+    // Each entry below marshals to 3 bytes ('"a"', '"b"', ...); a 5-byte
+    // budget holds one comfortably but forces an eviction once a second
+    // entry pushes memUsed past it.
+    c, err := New("", 5)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    c.Set("cheap", "a", CostSummary)
+    c.Set("expensive", "b", CostStructure)
+
+    var out string
+    if c.Get("cheap", &out) {
+        t.Fatal("Get(cheap) = true, want the low-cost entry evicted to make room for the high-cost one")
+    }
+    if !c.Get("expensive", &out) {
+        t.Fatal("Get(expensive) = false, want the high-cost entry to survive eviction")
+    }
+    if got := c.Stats(); got.Evictions == 0 {
+        t.Fatalf("Stats().Evictions = %d, want at least 1", got.Evictions)
+    }
+}
+
+func TestGetOrCreateCallsCreateOnceOnMiss(t *testing.T) {
+    c, err := New("", 1<<20)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    calls := 0
+    create := func() (string, error) {
+        calls++
+        return "fresh", nil
+    }
+
+    for i := 0; i < 3; i++ {
+        out, err := GetOrCreate(c, "k", CostSummary, create)
+        if err != nil {
+            t.Fatalf("GetOrCreate() error = %v", err)
+        }
+        if out != "fresh" {
+            t.Fatalf("GetOrCreate() = %q, want %q", out, "fresh")
+        }
+    }
+    if calls != 1 {
+        t.Fatalf("create was called %d times, want 1 (subsequent calls should hit the cache)", calls)
+    }
+}
+
+func TestGetOrCreatePropagatesCreateError(t *testing.T) {
+    c, err := New("", 1<<20)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    wantErr := errors.New("boom")
+    _, err = GetOrCreate(c, "k", CostSummary, func() (string, error) {
+        return "", wantErr
+    })
+    if !errors.Is(err, wantErr) {
+        t.Fatalf("GetOrCreate() error = %v, want %v", err, wantErr)
+    }
+    var out string
+    if c.Get("k", &out) {
+        t.Fatal("Get() = true after a failed create, want nothing stored")
+    }
+}
+
+// TestConcurrentGetSet exercises Get/Set from many goroutines on one key;
+// run with -race to confirm the stats counters stay inside the cache's lock.
+func TestConcurrentGetSet(t *testing.T) {
+    c, err := New("", 1<<20)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    var wg sync.WaitGroup
+    for i := 0; i < 50; i++ {
+        wg.Add(2)
+        go func() {
+            defer wg.Done()
+            c.Set("k", "v", CostSummary)
+        }()
+        go func() {
+            defer wg.Done()
+            var out string
+            c.Get("k", &out)
+        }()
+    }
+    wg.Wait()
+}
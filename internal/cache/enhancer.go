@@ -0,0 +1,89 @@
+package cache
+
+import (
+    "context"
+    "os"
+    "strconv"
+    "strings"
+
+    "github.com/thywilljoshua/pdf-to-docs/internal/ai"
+)
+
+// enhancer wraps an ai.Enhancer and short-circuits network calls when a
+// fingerprint-matching response is already cached.
+type enhancer struct {
+    next  ai.Enhancer
+    cache *Cache
+}
+
+// Wrap decorates next with c, returning an ai.Enhancer that serves cached
+// responses before falling through to next.
+func Wrap(next ai.Enhancer, c *Cache) ai.Enhancer {
+    if c == nil {
+        return next
+    }
+    return &enhancer{next: next, cache: c}
+}
+
+func (e *enhancer) RepairToC(ctx context.Context, raw []string) (ai.RepairedToC, error) {
+    key := "repairtoc:" + Fingerprint([]byte(strings.Join(raw, "\n")), []byte(e.next.ModelID()))
+    return GetOrCreate(e.cache, key, CostSummary, func() (ai.RepairedToC, error) {
+        return e.next.RepairToC(ctx, raw)
+    })
+}
+
+func (e *enhancer) Summarize(ctx context.Context, text string, maxTokens int) (string, error) {
+    key := "summarize:" + Fingerprint([]byte(text), []byte(strconv.Itoa(maxTokens)), []byte(e.next.ModelID()))
+    return GetOrCreate(e.cache, key, CostSummary, func() (string, error) {
+        return e.next.Summarize(ctx, text, maxTokens)
+    })
+}
+
+func (e *enhancer) SuggestComponents(ctx context.Context, text string, allow []string, mode string) (string, error) {
+    key := "components:" + Fingerprint([]byte(text), []byte(strings.Join(allow, ",")), []byte(mode), []byte(e.next.ModelID()))
+    return GetOrCreate(e.cache, key, CostComponent, func() (string, error) {
+        return e.next.SuggestComponents(ctx, text, allow, mode)
+    })
+}
+
+func (e *enhancer) Caption(ctx context.Context, imagePath string) (string, error) {
+    b, err := os.ReadFile(imagePath)
+    if err != nil {
+        return e.next.Caption(ctx, imagePath)
+    }
+    key := "caption:" + Fingerprint(b, []byte(mimeOf(imagePath)))
+    return GetOrCreate(e.cache, key, CostCaption, func() (string, error) {
+        return e.next.Caption(ctx, imagePath)
+    })
+}
+
+func (e *enhancer) ExtractStructure(ctx context.Context, pdfPath string, maxDepth, tocPages int) (ai.StructuredDoc, error) {
+    b, err := os.ReadFile(pdfPath)
+    if err != nil {
+        return e.next.ExtractStructure(ctx, pdfPath, maxDepth, tocPages)
+    }
+    key := "structure:" + Fingerprint(b, []byte(strconv.Itoa(maxDepth)), []byte(strconv.Itoa(tocPages)))
+    return GetOrCreate(e.cache, key, CostStructure, func() (ai.StructuredDoc, error) {
+        return e.next.ExtractStructure(ctx, pdfPath, maxDepth, tocPages)
+    })
+}
+
+// ModelID forwards to the wrapped Enhancer: caching doesn't change which
+// model produced a response.
+func (e *enhancer) ModelID() string { return e.next.ModelID() }
+
+func mimeOf(path string) string {
+    ext := strings.ToLower(path[strings.LastIndex(path, ".")+1:])
+    switch ext {
+    case "jpg", "jpeg":
+        return "image/jpeg"
+    case "png":
+        return "image/png"
+    case "gif":
+        return "image/gif"
+    case "webp":
+        return "image/webp"
+    default:
+        return "application/octet-stream"
+    }
+}
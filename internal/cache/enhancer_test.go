@@ -0,0 +1,57 @@
+package cache
+
+import (
+    "context"
+    "testing"
+
+    "github.com/thywilljoshua/pdf-to-docs/internal/ai"
+)
+
+// stubEnhancer is a minimal ai.Enhancer whose Summarize counts calls, so
+// tests can tell a cache hit (no call) from a cache miss (a call).
+type stubEnhancer struct {
+    model string
+    calls int
+}
+
+func (s *stubEnhancer) RepairToC(ctx context.Context, raw []string) (ai.RepairedToC, error) {
+    return ai.RepairedToC{Lines: raw}, nil
+}
+func (s *stubEnhancer) Summarize(ctx context.Context, text string, maxTokens int) (string, error) {
+    s.calls++
+    return "summary", nil
+}
+func (s *stubEnhancer) SuggestComponents(ctx context.Context, text string, allow []string, mode string) (string, error) {
+    return "", nil
+}
+func (s *stubEnhancer) Caption(ctx context.Context, imagePath string) (string, error) { return "", nil }
+func (s *stubEnhancer) ExtractStructure(ctx context.Context, pdfPath string, maxDepth, tocPages int) (ai.StructuredDoc, error) {
+    return ai.StructuredDoc{}, nil
+}
+func (s *stubEnhancer) ModelID() string { return s.model }
+
+func TestSummarizeCacheKeyIncludesModelID(t *testing.T) {
+    c, err := New("", 1<<20)
+    if err != nil {
+        t.Fatalf("New() error = %v", err)
+    }
+    next := &stubEnhancer{model: "model-a"}
+    wrapped := Wrap(next, c)
+
+    if _, err := wrapped.Summarize(context.Background(), "hello", 10); err != nil {
+        t.Fatalf("Summarize() error = %v", err)
+    }
+    if next.calls != 1 {
+        t.Fatalf("calls = %d, want 1 after the first Summarize", next.calls)
+    }
+
+    // Same text, same cache, but a different model: must not serve the
+    // other model's cached response.
+    next.model = "model-b"
+    if _, err := wrapped.Summarize(context.Background(), "hello", 10); err != nil {
+        t.Fatalf("Summarize() error = %v", err)
+    }
+    if next.calls != 2 {
+        t.Fatalf("calls = %d, want 2: switching model must invalidate the cached response", next.calls)
+    }
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+    "os"
+    "strconv"
+    "strings"
+)
+
+// systemMemoryBytes returns total physical RAM in bytes, or 0 if it cannot be
+// determined. Only /proc/meminfo (Linux) is consulted; other platforms fall
+// back to the caller's default.
+func systemMemoryBytes() int64 {
+    b, err := os.ReadFile("/proc/meminfo")
+    if err != nil {
+        return 0
+    }
+    for _, line := range strings.Split(string(b), "\n") {
+        if !strings.HasPrefix(line, "MemTotal:") {
+            continue
+        }
+        fields := strings.Fields(line)
+        if len(fields) < 2 {
+            return 0
+        }
+        kb, err := strconv.ParseInt(fields[1], 10, 64)
+        if err != nil {
+            return 0
+        }
+        return kb * 1024
+    }
+    return 0
+}
+
+// parseGB parses a float string like "2" or "1.5" into a float64; returns 0
+// on failure.
+func parseGB(s string) float64 {
+    f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+    if err != nil {
+        return 0
+    }
+    return f
+}
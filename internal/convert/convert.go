@@ -6,15 +6,43 @@ import (
     "os"
     "path/filepath"
     "regexp"
+    "runtime"
+    "sort"
     "strings"
+    "sync/atomic"
+    "time"
+
+    "github.com/thywilljoshua/pdf-to-docs/internal/ai"
+    "github.com/thywilljoshua/pdf-to-docs/internal/cache"
 )
 
 type runResult struct {
-    Sections []Section `json:"sections"`
-    Images   int       `json:"images_extracted"`
-    OutDir   string    `json:"out_dir"`
+    Sections   []Section       `json:"sections"`
+    Images     int             `json:"images_extracted"`
+    OutDir     string          `json:"out_dir"`
+    CacheStats *cache.Stats    `json:"cache_stats,omitempty"`
+    Written    int             `json:"sections_written"`
+    Unchanged  int             `json:"sections_unchanged"`
+    Timing     []SectionTiming `json:"section_timing,omitempty"`
+}
+
+// SectionTiming reports how long one section took Run to render and how
+// many of its Enhancer calls had to be retried, so a slow or flaky model
+// call is visible in the JSON report instead of only in logs.
+type SectionTiming struct {
+    Slug       string `json:"slug"`
+    DurationMs int64  `json:"duration_ms"`
+    Retries    int    `json:"retries"`
 }
 
+// resilienceRetries and resilienceBaseDelay bound the retry-with-backoff
+// wrapper Run puts around cfg.Enhancer so one rate-limited or flaky model
+// call can't stall or poison an entire run.
+const (
+    resilienceRetries   = 3
+    resilienceBaseDelay = 250 * time.Millisecond
+)
+
 func Run(ctx context.Context, pdfPath string, cfg Config) (runResult, error) {
     if cfg.OutDir == "" {
         cfg.OutDir = "."
@@ -23,18 +51,49 @@ func Run(ctx context.Context, pdfPath string, cfg Config) (runResult, error) {
         return runResult{}, err
     }
 
-    // Initialize minimal docs.json if it doesn't exist
-    docsPath := filepath.Join(cfg.OutDir, "docs.json")
-    if _, err := os.Stat(docsPath); os.IsNotExist(err) {
-        if err := initializeDocsJSON(docsPath, cfg.SiteName); err != nil {
-            return runResult{}, fmt.Errorf("failed to initialize docs.json: %w", err)
-        }
+    conc := cfg.Concurrency
+    if conc <= 0 {
+        conc = runtime.NumCPU()
+    }
+    aiConc := cfg.AIConcurrency
+    if aiConc <= 0 {
+        aiConc = 4
+    }
+    aiSem := newSemaphore(aiConc)
+
+    // pdfHash fingerprints the source PDF's raw bytes, so Run can tell it
+    // changed even where that isn't otherwise reflected in a section's own
+    // fingerprint (a re-exported PDF with identical extracted text, a
+    // ToC-only edit in the AI-exclusive path).
+    pdfHash := hashFile(pdfPath)
+
+    // enhancer is cfg.Enhancer wrapped with retries and a circuit breaker;
+    // sectionChanged below still fingerprints against cfg.Enhancer directly
+    // so the resilience wrapper doesn't itself invalidate the content map.
+    enhancer := cfg.Enhancer
+    if enhancer != nil {
+        enhancer = ai.WithResilience(enhancer, resilienceRetries, resilienceBaseDelay)
+    }
+
+    // siteName honors a top-level _site override file over cfg.SiteName, so
+    // a hand-tuned site name survives a re-run.
+    siteName := cfg.SiteName
+    if so, ok := loadSiteOverride(cfg.OutDir); ok && so.SiteName != "" {
+        siteName = so.SiteName
+    }
+
+    siteAllow := []string{"callout", "steps", "accordion"}
+    renderer := NewRenderer(cfg.Format, enhancer, siteAllow, aiSem)
+
+    // Initialize the target's site config (docs.json, sidebars.js, ...) if it doesn't exist
+    if err := renderer.InitSite(cfg.OutDir, siteName); err != nil {
+        return runResult{}, fmt.Errorf("failed to initialize site: %w", err)
     }
 
     // Gemini-exclusive path: use AI to extract structure and content
     if cfg.AIExclusive && cfg.Enhancer != nil {
         fmt.Println("🤖 Using Gemini AI to extract PDF structure...")
-        doc, err := cfg.Enhancer.ExtractStructure(ctx, pdfPath, cfg.MaxDepth, cfg.ToCPages)
+        doc, err := enhancer.ExtractStructure(ctx, pdfPath, cfg.MaxDepth, cfg.ToCPages)
         if err != nil {
             return runResult{}, fmt.Errorf("AI extraction failed: %w", err)
         }
@@ -53,21 +112,16 @@ func Run(ctx context.Context, pdfPath string, cfg Config) (runResult, error) {
 
         // Process only depth-1 sections (top-level)
         // Each depth-1 section's text already contains all subsections formatted as markdown headers
+        cm := loadContentMap(cfg.OutDir)
         var sections []Section
+        var jobs []sectionJob
         for _, s := range doc.Sections {
             // Only process depth-1 sections
             if s.Depth != 1 {
                 continue
             }
 
-            sec := Section{
-                Number: s.Number,
-                Title:  s.Title,
-                Start:  s.Start,
-                End:    s.End,
-                Depth:  s.Depth,
-                Slug:   slugify(s.Title), // Use title only, not number
-            }
+            sec := NewPDFSection(s.Number, s.Title, s.Start, s.End, s.Depth, slugify(s.Title)) // Use title only, not number
             sections = append(sections, sec)
 
             // Create single MDX file with all content (subsections already in s.Text as markdown headers)
@@ -76,12 +130,58 @@ func Run(ctx context.Context, pdfPath string, cfg Config) (runResult, error) {
             if cfg.KeepImages {
                 imgs = discoverImagesForRange(imgDir, s.Start, s.End)
             }
-            _, _ = writeMDX(ctx, cfg.OutDir, sec, pageTexts, imgs, []string{"callout","steps","accordion"}, cfg.Enhancer)
+            jobs = append(jobs, sectionJob{sec: sec, pageTexts: pageTexts, imgs: imgs})
+        }
+        var draftSkipped int
+        jobs, draftSkipped = applyOverrides(cfg.OutDir, jobs)
+        sections = sectionsFromJobs(jobs)
+
+        // configHash covers everything this path's ExtractStructure call
+        // produces from scratch each run, since there's no per-section ToC
+        // line of its own to fingerprint against.
+        configHash := hashString(fmt.Sprintf("%s|%d|%d|%s", pdfHash, cfg.MaxDepth, cfg.ToCPages, cfg.Enhancer.ModelID()))
+
+        // Errors are ignored here (as in the original sequential path) since
+        // this is a best-effort, AI-exclusive extraction.
+        outcomes := renderSectionsParallel(ctx, renderer, cfg, cm, cfg.Enhancer, jobs, conc, configHash)
+        written, unchanged := 0, 0
+        var timing []SectionTiming
+        for i, oc := range outcomes {
+            if cfg.Force || oc.changed {
+                written++
+            } else {
+                unchanged++
+            }
+            if oc.timing != nil {
+                timing = append(timing, *oc.timing)
+            }
+            cm.Sections[jobs[i].sec.Slug()] = oc.fresh
+        }
+        if err := saveContentMap(cfg.OutDir, cm); err != nil {
+            return runResult{}, err
         }
 
-        if err := updateDocsJSON(docsPath, cfg.SiteName, sections); err != nil { return runResult{}, err }
-        if err := writeIndex(cfg.OutDir, sections); err != nil { return runResult{}, err }
-        return runResult{Sections: sections, Images: imgCount, OutDir: cfg.OutDir}, nil
+        if cfg.Include != "" {
+            entries, err := loadIncludeManifest(cfg.Include)
+            if err != nil {
+                return runResult{}, fmt.Errorf("failed to load --include manifest: %w", err)
+            }
+            sections = spliceIncludes(sections, entries, filepath.Dir(cfg.Include))
+            if err := renderIncluded(ctx, cfg.OutDir, renderer, sections); err != nil {
+                return runResult{}, fmt.Errorf("failed to render included sections: %w", err)
+            }
+        }
+
+        if err := renderer.UpdateNavigation(cfg.OutDir, siteName, sections); err != nil {
+            return runResult{}, err
+        }
+        if _, ok := renderer.(*mintlifyRenderer); ok {
+            if err := writeIndex(cfg.OutDir, sections); err != nil {
+                return runResult{}, err
+            }
+        }
+        fmt.Printf("built %d, cached %d, skipped %d\n", written, unchanged, draftSkipped)
+        return newRunResult(sections, imgCount, cfg, written, unchanged, timing), nil
     }
 
     pages, err := extractTextPerPage(pdfPath)
@@ -89,18 +189,23 @@ func Run(ctx context.Context, pdfPath string, cfg Config) (runResult, error) {
         return runResult{}, err
     }
 
+    schemes := resolveSchemes(cfg.TOCSchemes)
     var tocLines []string
     if cfg.UseToC {
         tocLines = findToCMultiPage(pages, cfg.ToCPages)
         if cfg.Enhancer != nil && len(tocLines) > 0 {
-            if repaired, err := cfg.Enhancer.RepairToC(ctx, tocLines); err == nil && len(repaired) > 0 {
-                tocLines = repaired
+            if repaired, err := enhancer.RepairToC(ctx, tocLines); err == nil && len(repaired.Lines) > 0 {
+                tocLines = repaired.Lines
+                schemes = prioritizeScheme(schemes, repaired.SchemeHint)
             }
         }
     }
+    if cfg.DumpToC {
+        dumpTOC(tocLines, schemes)
+    }
     var sections []Section
     if len(tocLines) > 0 {
-        entries := parseToCLines(tocLines)
+        entries := parseToCLines(tocLines, schemes)
         sections = buildSections(entries, cfg.MaxDepth)
     } else {
         sections = fallbackSections(pages, cfg.FallbackSplit)
@@ -114,30 +219,66 @@ func Run(ctx context.Context, pdfPath string, cfg Config) (runResult, error) {
         }
     }
 
+    cm := loadContentMap(cfg.OutDir)
+    jobs := make([]sectionJob, len(sections))
     for i, s := range sections {
-        pageTexts := collectTextByPage(pages, s.Start, s.End)
+        pageTexts := collectTextByPage(pages, s.Start(), s.End())
         if cfg.SlugPrefix != "" {
-            s.Slug = slugify(cfg.SlugPrefix + "-" + s.Slug)
+            s.SetSlug(slugify(cfg.SlugPrefix + "-" + s.Slug()))
         }
-        sections[i] = s
         var imgs []ImageRef
         if cfg.KeepImages {
-            imgs = discoverImagesForRange(imgDir, s.Start, s.End)
+            imgs = discoverImagesForRange(imgDir, s.Start(), s.End())
         }
-        if _, err := writeMDX(ctx, cfg.OutDir, s, pageTexts, imgs, []string{"callout", "steps", "accordion"}, cfg.Enhancer); err != nil {
-            return runResult{}, err
+        jobs[i] = sectionJob{sec: s, pageTexts: pageTexts, imgs: imgs}
+    }
+    var draftSkipped int
+    jobs, draftSkipped = applyOverrides(cfg.OutDir, jobs)
+    sections = sectionsFromJobs(jobs)
+    outcomes := renderSectionsParallel(ctx, renderer, cfg, cm, cfg.Enhancer, jobs, conc, pdfHash)
+    written, unchanged := 0, 0
+    var timing []SectionTiming
+    for i, oc := range outcomes {
+        if oc.err != nil {
+            return runResult{}, oc.err
+        }
+        if cfg.Force || oc.changed {
+            written++
+        } else {
+            unchanged++
+        }
+        if oc.timing != nil {
+            timing = append(timing, *oc.timing)
+        }
+        cm.Sections[jobs[i].sec.Slug()] = oc.fresh
+    }
+    if err := saveContentMap(cfg.OutDir, cm); err != nil {
+        return runResult{}, err
+    }
+
+    if cfg.Include != "" {
+        entries, err := loadIncludeManifest(cfg.Include)
+        if err != nil {
+            return runResult{}, fmt.Errorf("failed to load --include manifest: %w", err)
+        }
+        sections = spliceIncludes(sections, entries, filepath.Dir(cfg.Include))
+        if err := renderIncluded(ctx, cfg.OutDir, renderer, sections); err != nil {
+            return runResult{}, fmt.Errorf("failed to render included sections: %w", err)
         }
     }
 
     // Build hierarchy for nested navigation
     tree := buildHierarchy(sections)
-    if err := updateDocsJSON(docsPath, cfg.SiteName, tree); err != nil {
+    if err := renderer.UpdateNavigation(cfg.OutDir, siteName, tree); err != nil {
         return runResult{}, err
     }
-    if err := writeIndex(cfg.OutDir, filterTopLevel(sections)); err != nil {
-        return runResult{}, err
+    if _, ok := renderer.(*mintlifyRenderer); ok {
+        if err := writeIndex(cfg.OutDir, filterTopLevel(sections)); err != nil {
+            return runResult{}, err
+        }
     }
-    return runResult{Sections: sections, Images: imgCount, OutDir: cfg.OutDir}, nil
+    fmt.Printf("built %d, cached %d, skipped %d\n", written, unchanged, draftSkipped)
+    return newRunResult(sections, imgCount, cfg, written, unchanged, timing), nil
 }
 
 type PageText struct {
@@ -262,27 +403,27 @@ func fallbackSections(pages []string, mode string) []Section {
     if mode == "heading" {
         // Basic heading-based split: start new section when a line looks like a heading
         heading := regexp.MustCompile(`^[A-Z][A-Za-z0-9 ,\-/()]{3,}$`)
-        cur := Section{Number: "1", Title: "Section 1", Start: 1, End: 1, Depth: 1, Slug: slugify("1-section-1")}
+        cur := NewPDFSection("1", "Section 1", 1, 1, 1, slugify("1-section-1"))
         idx := 1
         for i := 1; i <= len(pages); i++ {
             lines := strings.Split(pages[i-1], "\n")
             for _, ln := range lines {
-                if heading.MatchString(strings.TrimSpace(ln)) && i != cur.Start {
-                    cur.End = i - 1
+                if heading.MatchString(strings.TrimSpace(ln)) && i != cur.start {
+                    cur.end = i - 1
                     out = append(out, cur)
                     idx++
-                    cur = Section{Number: fmt.Sprintf("%d", idx), Title: strings.TrimSpace(ln), Start: i, End: i, Depth: 1, Slug: slugify(fmt.Sprintf("%d-%s", idx, ln))}
+                    cur = NewPDFSection(fmt.Sprintf("%d", idx), strings.TrimSpace(ln), i, i, 1, slugify(fmt.Sprintf("%d-%s", idx, ln)))
                     break
                 }
             }
-            cur.End = i
+            cur.end = i
         }
         out = append(out, cur)
         return out
     }
     for i := 1; i <= len(pages); i++ {
         t := fmt.Sprintf("Page %d", i)
-        out = append(out, Section{Number: fmt.Sprintf("%d", i), Title: t, Start: i, End: i, Depth: 1, Slug: slugify(t)})
+        out = append(out, NewPDFSection(fmt.Sprintf("%d", i), t, i, i, 1, slugify(t)))
     }
     return out
 }
@@ -290,7 +431,7 @@ func fallbackSections(pages []string, mode string) []Section {
 func filterTopLevel(sections []Section) []Section {
     var out []Section
     for _, s := range sections {
-        if s.Depth == 1 {
+        if s.Depth() == 1 {
             out = append(out, s)
         }
     }
@@ -337,12 +478,117 @@ func atoi(s string) int {
     return n
 }
 
+// newRunResult assembles the final report, attaching cache counters when
+// cfg.Cache is set so callers can see hit/miss/eviction activity.
+func newRunResult(sections []Section, imgCount int, cfg Config, written, unchanged int, timing []SectionTiming) runResult {
+    res := runResult{Sections: sections, Images: imgCount, OutDir: cfg.OutDir, Written: written, Unchanged: unchanged, Timing: timing}
+    if cfg.Cache != nil {
+        stats := cfg.Cache.Stats()
+        res.CacheStats = &stats
+    }
+    return res
+}
+
+// sectionJob bundles one section's render inputs so renderSectionsParallel
+// can fan a batch out across goroutines and fold results back in source
+// order afterward.
+type sectionJob struct {
+    sec       Section
+    pageTexts []PageText
+    imgs      []ImageRef
+}
+
+// applyOverrides applies each job's _section override file (if any) before
+// rendering: defaulting Name to Slug, merging in the override's Title,
+// Params, and Weight, dropping Draft sections outright, and finally
+// sort.SliceStable-ing the remainder by Weight so a hand-tuned ordering
+// survives a re-run without touching buildHierarchy, UpdateNavigation, or
+// writeIndex, which just iterate whatever jobs/sections they're given.
+// applyOverrides also reports how many jobs were dropped as Draft, so Run
+// can fold that into its "built N, cached M, skipped K" summary.
+func applyOverrides(outDir string, jobs []sectionJob) (kept []sectionJob, skipped int) {
+    kept = jobs[:0]
+    for _, j := range jobs {
+        j.sec.SetName(j.sec.Slug())
+        if o, ok := loadSectionOverride(outDir, j.sec.Slug()); ok {
+            applySectionOverride(j.sec, o)
+        }
+        if j.sec.Draft() {
+            skipped++
+            continue
+        }
+        kept = append(kept, j)
+    }
+    sort.SliceStable(kept, func(i, k int) bool { return kept[i].sec.Weight() < kept[k].sec.Weight() })
+    return kept, skipped
+}
+
+// sectionsFromJobs rebuilds a []Section from jobs, keeping it in sync with
+// whatever applyOverrides filtered and reordered.
+func sectionsFromJobs(jobs []sectionJob) []Section {
+    sections := make([]Section, len(jobs))
+    for i, j := range jobs {
+        sections[i] = j.sec
+    }
+    return sections
+}
+
+// sectionOutcome is one job's result: its fresh content-map fingerprint,
+// whether it changed, and (if it was rendered) its timing/retry counts and
+// any write error.
+type sectionOutcome struct {
+    fresh   sectionState
+    changed bool
+    timing  *SectionTiming
+    err     error
+}
+
+// renderSectionsParallel fans jobs out across up to conc goroutines via
+// runParallel, rendering each changed section through renderer.WriteSection
+// with its own retry counter so per-section timing and retry counts can be
+// reported despite sections rendering concurrently. hashEnhancer is passed
+// through to sectionChanged unwrapped, so retry/circuit-breaker plumbing
+// around the Enhancer doesn't itself invalidate the content map. configHash
+// is the part of the fingerprint constant across every job in this call
+// (source PDF hash, plus AI-exclusive's MaxDepth/ToCPages/ModelID). If
+// cfg.Why names a job's slug, its fingerprint diff is printed. Results are
+// returned indexed identically to jobs; callers fold them back in source
+// order so the persisted content map and docs.json navigation stay
+// byte-for-byte identical to a single-threaded run.
+func renderSectionsParallel(ctx context.Context, renderer Renderer, cfg Config, cm contentMap, hashEnhancer sectionEnhancer, jobs []sectionJob, conc int, configHash string) []sectionOutcome {
+    out := make([]sectionOutcome, len(jobs))
+    runParallel(len(jobs), conc, func(i int) {
+        job := jobs[i]
+        fresh, changed := sectionChanged(cm, cfg.OutDir, job.sec, job.pageTexts, job.imgs, hashEnhancer, configHash)
+        if cfg.Why != "" && job.sec.Slug() == cfg.Why {
+            prev, ok := cm.Sections[job.sec.Slug()]
+            reasons := diffReasons(prev, fresh, ok)
+            if len(reasons) == 0 {
+                fmt.Printf("why %s: unchanged\n", job.sec.Slug())
+            } else {
+                fmt.Printf("why %s: %s\n", job.sec.Slug(), strings.Join(reasons, ", "))
+            }
+        }
+        outcome := sectionOutcome{fresh: fresh, changed: changed}
+        if cfg.Force || changed {
+            var retries atomic.Int32
+            rctx := ai.WithRetryCount(ctx, &retries)
+            start := time.Now()
+            _, err := renderer.WriteSection(rctx, cfg.OutDir, job.sec, job.pageTexts, job.imgs)
+            outcome.err = err
+            outcome.timing = &SectionTiming{Slug: job.sec.Slug(), DurationMs: time.Since(start).Milliseconds(), Retries: int(retries.Load())}
+        }
+        out[i] = outcome
+    })
+    return out
+}
+
 func writeIndex(outDir string, tops []Section) error {
     var b strings.Builder
     b.WriteString("---\ntitle: \"Introduction\"\ndescription: \"Auto-generated overview\"\n---\n\n")
     b.WriteString("## Sections\n\n")
     for _, s := range tops {
-        b.WriteString(fmt.Sprintf("- [%s %s](./%s)\n", s.Number, s.Title, s.Slug))
+        b.WriteString(fmt.Sprintf("- [%s %s](./%s)\n", s.Number(), s.Title(), s.Slug()))
     }
     path := filepath.Join(outDir, "index.mdx")
     return os.WriteFile(path, []byte(b.String()), 0o644)
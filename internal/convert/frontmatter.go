@@ -0,0 +1,76 @@
+package convert
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/thywilljoshua/pdf-to-docs/internal/frontmatter"
+)
+
+// FrontMatterConfig configures a round-trip conversion pass over an
+// already-generated docs tree's front matter, independent of a fresh
+// convert.Run.
+type FrontMatterConfig struct {
+    OutDir string
+    // To is the target front matter format for every page under OutDir.
+    To frontmatter.Format
+}
+
+// FrontMatterResult reports what ConvertFrontMatter did.
+type FrontMatterResult struct {
+    Converted int      `json:"converted"`
+    Skipped   int      `json:"skipped"`
+    Files     []string `json:"files,omitempty"`
+}
+
+// ConvertFrontMatter walks cfg.OutDir for .md/.mdx pages, reparses each
+// one's front matter via frontmatter.ParseFrontMatterAndContent, and
+// rewrites it in cfg.To, leaving the body untouched. A page with no front
+// matter (frontmatter.None) is left on disk as-is and counted as skipped.
+func ConvertFrontMatter(ctx context.Context, cfg FrontMatterConfig) (FrontMatterResult, error) {
+    var res FrontMatterResult
+    err := filepath.Walk(cfg.OutDir, func(path string, info os.FileInfo, err error) error {
+        if err != nil {
+            return err
+        }
+        if err := ctx.Err(); err != nil {
+            return err
+        }
+        if info.IsDir() {
+            return nil
+        }
+        switch strings.ToLower(filepath.Ext(path)) {
+        case ".md", ".mdx":
+        default:
+            return nil
+        }
+
+        f, err := os.Open(path)
+        if err != nil {
+            return err
+        }
+        page, err := frontmatter.ParseFrontMatterAndContent(f)
+        f.Close()
+        if err != nil {
+            return err
+        }
+        if page.Format == frontmatter.None {
+            res.Skipped++
+            return nil
+        }
+
+        out, err := frontmatter.Serialize(cfg.To, page.FrontMatter, page.Content)
+        if err != nil {
+            return err
+        }
+        if err := os.WriteFile(path, out, 0o644); err != nil {
+            return err
+        }
+        res.Converted++
+        res.Files = append(res.Files, path)
+        return nil
+    })
+    return res, err
+}
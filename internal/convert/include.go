@@ -0,0 +1,128 @@
+package convert
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "gopkg.in/yaml.v3"
+)
+
+// IncludeEntry describes one extra, non-PDF section to splice into the
+// generated tree via Config.Include. File, if set, is copied through as a
+// fileSection; otherwise the entry becomes a content-less groupSection.
+type IncludeEntry struct {
+    Title      string `yaml:"title" json:"title"`
+    File       string `yaml:"file" json:"file"`
+    Position   string `yaml:"position" json:"position"` // before|after|child_of (default: after)
+    RelativeTo string `yaml:"relative_to" json:"relative_to"`
+}
+
+type includeManifest struct {
+    Sections []IncludeEntry `yaml:"sections" json:"sections"`
+}
+
+// loadIncludeManifest reads a --include manifest, choosing YAML or JSON by
+// the file's extension (defaulting to YAML).
+func loadIncludeManifest(path string) ([]IncludeEntry, error) {
+    b, err := os.ReadFile(path)
+    if err != nil {
+        return nil, err
+    }
+    var m includeManifest
+    if strings.EqualFold(filepath.Ext(path), ".json") {
+        err = json.Unmarshal(b, &m)
+    } else {
+        err = yaml.Unmarshal(b, &m)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("parsing %s: %w", path, err)
+    }
+    return m.Sections, nil
+}
+
+// spliceIncludes inserts each manifest entry into sections, positioned
+// before/after/as a child of the section whose Number matches RelativeTo.
+// An entry whose RelativeTo is empty or unmatched is appended as a new
+// top-level section so nothing silently vanishes from the tree.
+func spliceIncludes(sections []Section, entries []IncludeEntry, baseDir string) []Section {
+    for i, e := range entries {
+        number := fmt.Sprintf("include-%d", i+1)
+        var sec Section
+        if e.File == "" {
+            sec = NewGroupSection(number, e.Title, 1)
+        } else {
+            sec = NewFileSection(filepath.Join(baseDir, e.File), number, e.Title, slugify(e.Title))
+        }
+
+        if e.RelativeTo == "" {
+            sections = append(sections, sec)
+            continue
+        }
+        if updated, ok := insertSection(sections, sec, e.Position, e.RelativeTo, ""); ok {
+            sections = updated
+        } else {
+            sections = append(sections, sec)
+        }
+    }
+    return sections
+}
+
+// insertSection searches nodes (and recursively their children) for the
+// section numbered relativeTo, and inserts s there per position. parentPath
+// is the TreePath of nodes' own parent ("" at the top level), used to give s
+// a TreePath nested under the right ancestor instead of the flat,
+// non-nesting default buildHierarchy would otherwise assign it (the
+// synthetic "include-N" Number has no dots to split into a path), so a
+// relativeTo naming a nested section still lands s inside that section's
+// parent rather than becoming a spurious new root. It reports whether a
+// match was found.
+func insertSection(nodes []Section, s Section, position, relativeTo, parentPath string) ([]Section, bool) {
+    for i, n := range nodes {
+        if n.Number() != relativeTo {
+            continue
+        }
+        switch position {
+        case "child_of":
+            s.SetTreePath(joinTreePath(n.TreePath(), s.Number()))
+            n.AppendChild(s)
+            return nodes, true
+        case "before":
+            s.SetTreePath(joinTreePath(parentPath, s.Number()))
+            return spliceAt(nodes, i, s), true
+        default: // "after" and anything else
+            s.SetTreePath(joinTreePath(parentPath, s.Number()))
+            return spliceAt(nodes, i+1, s), true
+        }
+    }
+    for _, n := range nodes {
+        children := n.Children()
+        if len(children) == 0 {
+            continue
+        }
+        if updated, ok := insertSection(children, s, position, relativeTo, n.TreePath()); ok {
+            n.SetChildren(updated)
+            return nodes, true
+        }
+    }
+    return nodes, false
+}
+
+// joinTreePath joins parentPath and segment into a sectiontree path,
+// omitting the separating slash at the top level (parentPath == "").
+func joinTreePath(parentPath, segment string) string {
+    if parentPath == "" {
+        return segment
+    }
+    return parentPath + "/" + segment
+}
+
+func spliceAt(nodes []Section, idx int, s Section) []Section {
+    out := make([]Section, 0, len(nodes)+1)
+    out = append(out, nodes[:idx]...)
+    out = append(out, s)
+    out = append(out, nodes[idx:]...)
+    return out
+}
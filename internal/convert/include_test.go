@@ -0,0 +1,99 @@
+package convert
+
+import "testing"
+
+func TestSpliceIncludesTopLevelBeforeAfter(t *testing.T) {
+    sections := []Section{
+        NewPDFSection("1", "One", 1, 1, 1, "one"),
+        NewPDFSection("2", "Two", 2, 2, 1, "two"),
+    }
+    entries := []IncludeEntry{
+        {Title: "Before Two", Position: "before", RelativeTo: "2"},
+        {Title: "After One", Position: "after", RelativeTo: "1"},
+    }
+
+    spliced := spliceIncludes(sections, entries, "")
+    var titles []string
+    for _, s := range spliced {
+        titles = append(titles, s.Title())
+    }
+    want := []string{"One", "After One", "Before Two", "Two"}
+    if len(titles) != len(want) {
+        t.Fatalf("titles = %v, want %v", titles, want)
+    }
+    for i := range want {
+        if titles[i] != want[i] {
+            t.Fatalf("titles = %v, want %v", titles, want)
+        }
+    }
+
+    hierarchy := buildHierarchy(spliced)
+    if len(hierarchy) != len(want) {
+        t.Fatalf("buildHierarchy() produced %d roots, want %d (flat top-level sections shouldn't nest)", len(hierarchy), len(want))
+    }
+}
+
+func TestSpliceIncludesChildOf(t *testing.T) {
+    sections := []Section{NewPDFSection("1", "One", 1, 1, 1, "one")}
+    entries := []IncludeEntry{
+        {Title: "Child", Position: "child_of", RelativeTo: "1"},
+    }
+
+    spliced := spliceIncludes(sections, entries, "")
+    if len(spliced) != 1 {
+        t.Fatalf("spliceIncludes() = %d top-level sections, want 1 (child_of shouldn't add a root)", len(spliced))
+    }
+    children := spliced[0].Children()
+    if len(children) != 1 || children[0].Title() != "Child" {
+        t.Fatalf("spliced[0].Children() = %v, want a single Child section", children)
+    }
+}
+
+// TestSpliceIncludesNestedBeforeAfter covers the case a relative_to names a
+// nested section (not a top-level one): the spliced section must land
+// inside the matched section's own parent, rather than becoming a spurious
+// new root once buildHierarchy runs.
+func TestSpliceIncludesNestedBeforeAfter(t *testing.T) {
+    parent := NewPDFSection("2", "Parent", 1, 10, 1, "parent")
+    childA := NewPDFSection("2.1", "Child A", 1, 3, 2, "child-a")
+    childB := NewPDFSection("2.2", "Child B", 4, 6, 2, "child-b")
+    parent.AppendChild(childA)
+    parent.AppendChild(childB)
+
+    sections := []Section{parent}
+    entries := []IncludeEntry{
+        {Title: "Between Children", Position: "after", RelativeTo: "2.1"},
+    }
+
+    spliced := spliceIncludes(sections, entries, "")
+    if len(spliced) != 1 {
+        t.Fatalf("spliceIncludes() = %d top-level sections, want 1 (the new section should nest under Parent, not become a root)", len(spliced))
+    }
+
+    children := spliced[0].Children()
+    var titles []string
+    for _, c := range children {
+        titles = append(titles, c.Title())
+    }
+    want := []string{"Child A", "Between Children", "Child B"}
+    if len(titles) != len(want) {
+        t.Fatalf("Parent.Children() titles = %v, want %v", titles, want)
+    }
+    for i := range want {
+        if titles[i] != want[i] {
+            t.Fatalf("Parent.Children() titles = %v, want %v", titles, want)
+        }
+    }
+}
+
+func TestSpliceIncludesUnmatchedRelativeToAppendsAsRoot(t *testing.T) {
+    sections := []Section{NewPDFSection("1", "One", 1, 1, 1, "one")}
+    entries := []IncludeEntry{
+        {Title: "Orphan", Position: "after", RelativeTo: "9"},
+    }
+
+    spliced := spliceIncludes(sections, entries, "")
+    if len(spliced) != 2 || spliced[1].Title() != "Orphan" {
+        t.Fatalf("spliceIncludes() = %v, want the unmatched entry appended as a new top-level section", spliced)
+    }
+}
@@ -0,0 +1,130 @@
+package convert
+
+import (
+    "os"
+    "path/filepath"
+
+    "github.com/thywilljoshua/pdf-to-docs/internal/frontmatter"
+)
+
+// overrideExts are the formats a _section/_site override file may be
+// written in, tried in this order.
+var overrideExts = []struct {
+    ext    string
+    format frontmatter.Format
+}{
+    {".yaml", frontmatter.YAML},
+    {".yml", frontmatter.YAML},
+    {".toml", frontmatter.TOML},
+    {".json", frontmatter.JSON},
+}
+
+// sectionOverride is the shape read from a sibling
+// <outDir>/<slug>/_section.{yaml,toml,json} file: a stable place to
+// hand-tune AI/TOC output without edits getting clobbered on re-run.
+type sectionOverride struct {
+    Title  string
+    Name   string
+    Params map[string]any
+    Weight int
+    Draft  bool
+}
+
+// siteOverride is the shape read from the top-level
+// <outDir>/_site.{yaml,toml,json} file.
+type siteOverride struct {
+    SiteName string
+    Params   map[string]any
+}
+
+// loadSectionOverride looks for outDir/<slug>/_section.{yaml,toml,json}. A
+// missing file is not an error; it just means the section has no
+// hand-tuned override.
+func loadSectionOverride(outDir, slug string) (*sectionOverride, bool) {
+    m, ok := loadOverrideFile(filepath.Join(outDir, slug, "_section"))
+    if !ok {
+        return nil, false
+    }
+    return &sectionOverride{
+        Title:  stringField(m, "title"),
+        Name:   stringField(m, "name"),
+        Params: mapField(m, "params"),
+        Weight: intField(m, "weight"),
+        Draft:  boolField(m, "draft"),
+    }, true
+}
+
+// loadSiteOverride looks for outDir/_site.{yaml,toml,json}.
+func loadSiteOverride(outDir string) (*siteOverride, bool) {
+    m, ok := loadOverrideFile(filepath.Join(outDir, "_site"))
+    if !ok {
+        return nil, false
+    }
+    return &siteOverride{
+        SiteName: stringField(m, "site_name"),
+        Params:   mapField(m, "params"),
+    }, true
+}
+
+// loadOverrideFile tries each extension in overrideExts against base in
+// turn, parsing the first one found as a bare front-matter-shaped document
+// (no "---"/"+++" fence — the whole file is the document).
+func loadOverrideFile(base string) (map[string]any, bool) {
+    for _, c := range overrideExts {
+        b, err := os.ReadFile(base + c.ext)
+        if err != nil {
+            continue
+        }
+        m, err := frontmatter.DecodeDocument(c.format, b)
+        if err != nil {
+            continue
+        }
+        return m, true
+    }
+    return nil, false
+}
+
+// applySectionOverride merges o onto s: an empty Title/Name leaves s's
+// existing value alone, while Params, Weight, and Draft always take o's
+// value (their own zero values are meaningful: no extra params, default
+// ordering, not a draft).
+func applySectionOverride(s Section, o *sectionOverride) {
+    if o.Title != "" {
+        s.SetTitleOverride(o.Title)
+    }
+    if o.Name != "" {
+        s.SetName(o.Name)
+    }
+    s.SetParams(o.Params)
+    s.SetWeight(o.Weight)
+    s.SetDraft(o.Draft)
+}
+
+func stringField(m map[string]any, key string) string {
+    s, _ := m[key].(string)
+    return s
+}
+
+func mapField(m map[string]any, key string) map[string]any {
+    v, _ := m[key].(map[string]any)
+    return v
+}
+
+func boolField(m map[string]any, key string) bool {
+    b, _ := m[key].(bool)
+    return b
+}
+
+// intField accepts int, int64, and float64 since YAML, TOML, and JSON
+// decode an integer front-matter value into different concrete types.
+func intField(m map[string]any, key string) int {
+    switch v := m[key].(type) {
+    case int:
+        return v
+    case int64:
+        return int(v)
+    case float64:
+        return int(v)
+    }
+    return 0
+}
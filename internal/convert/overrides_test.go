@@ -0,0 +1,77 @@
+package convert
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestLoadSectionOverrideYAML(t *testing.T) {
+    dir := t.TempDir()
+    secDir := filepath.Join(dir, "intro")
+    if err := os.MkdirAll(secDir, 0o755); err != nil {
+        t.Fatalf("MkdirAll() error = %v", err)
+    }
+    yaml := "title: Introduction\nweight: 5\ndraft: true\nparams:\n  icon: book\n"
+    if err := os.WriteFile(filepath.Join(secDir, "_section.yaml"), []byte(yaml), 0o644); err != nil {
+        t.Fatalf("WriteFile() error = %v", err)
+    }
+
+    o, ok := loadSectionOverride(dir, "intro")
+    if !ok {
+        t.Fatal("loadSectionOverride() = false, want true")
+    }
+    if o.Title != "Introduction" || o.Weight != 5 || !o.Draft {
+        t.Fatalf("loadSectionOverride() = %+v, want Title=Introduction Weight=5 Draft=true", o)
+    }
+    if o.Params["icon"] != "book" {
+        t.Fatalf("loadSectionOverride().Params = %v, want icon=book", o.Params)
+    }
+}
+
+func TestLoadSectionOverrideMissingFile(t *testing.T) {
+    if _, ok := loadSectionOverride(t.TempDir(), "missing"); ok {
+        t.Fatal("loadSectionOverride() = true for a section with no override file, want false")
+    }
+}
+
+func TestApplySectionOverridePreservesTitleWhenEmpty(t *testing.T) {
+    s := NewPDFSection("1", "Original", 1, 2, 1, "original")
+    applySectionOverride(s, &sectionOverride{Weight: 3})
+
+    if got := s.Title(); got != "Original" {
+        t.Fatalf("Title() = %q, want %q unchanged by an empty override Title", got, "Original")
+    }
+    if got := s.Weight(); got != 3 {
+        t.Fatalf("Weight() = %d, want 3", got)
+    }
+}
+
+func TestApplySectionOverrideSetsTitleOverride(t *testing.T) {
+    s := NewPDFSection("1", "Original", 1, 2, 1, "original")
+    applySectionOverride(s, &sectionOverride{Title: "Renamed"})
+
+    if got := s.Title(); got != "Renamed" {
+        t.Fatalf("Title() = %q, want %q", got, "Renamed")
+    }
+}
+
+func TestIntFieldAcceptsYAMLJSONTOMLNumericTypes(t *testing.T) {
+    cases := []struct {
+        name string
+        val  any
+        want int
+    }{
+        {"int", 7, 7},
+        {"int64", int64(7), 7},
+        {"float64", float64(7), 7},
+    }
+    for _, c := range cases {
+        t.Run(c.name, func(t *testing.T) {
+            got := intField(map[string]any{"weight": c.val}, "weight")
+            if got != c.want {
+                t.Fatalf("intField() = %d, want %d", got, c.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,63 @@
+package convert
+
+import (
+    "context"
+    "sync"
+)
+
+// semaphore is a simple counting semaphore used to cap concurrent AI calls
+// (captions, summaries, component suggestions) independently of the section
+// worker pool's concurrency, so a high --concurrency doesn't overwhelm the
+// AI provider's rate limits.
+type semaphore chan struct{}
+
+func newSemaphore(n int) semaphore {
+    if n <= 0 {
+        n = 1
+    }
+    return make(semaphore, n)
+}
+
+func (s semaphore) Acquire(ctx context.Context) error {
+    select {
+    case s <- struct{}{}:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+func (s semaphore) Release() { <-s }
+
+// runParallel calls fn(i) for every i in [0, n) across at most workers
+// goroutines and blocks until all have returned. fn is responsible for
+// capturing its own result (by index, into a pre-sized slice) and error
+// handling; runParallel itself never aborts early.
+func runParallel(n, workers int, fn func(i int)) {
+    if n == 0 {
+        return
+    }
+    if workers <= 0 {
+        workers = 1
+    }
+    if workers > n {
+        workers = n
+    }
+    idx := make(chan int, n)
+    for i := 0; i < n; i++ {
+        idx <- i
+    }
+    close(idx)
+
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range idx {
+                fn(i)
+            }
+        }()
+    }
+    wg.Wait()
+}
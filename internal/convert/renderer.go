@@ -0,0 +1,179 @@
+package convert
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+
+    "github.com/thywilljoshua/pdf-to-docs/internal/frontmatter"
+)
+
+// Renderer shapes how a Section tree is written to disk and wired into a
+// particular documentation generator's navigation format. Mintlify is the
+// original, hard-coded behavior; Docusaurus, Starlight, and MkDocs plug in
+// alongside it via --format.
+type Renderer interface {
+    // WriteSection renders one section's pages/images to disk and returns
+    // the path of the file it wrote.
+    WriteSection(ctx context.Context, outDir string, s Section, pageTexts []PageText, images []ImageRef) (string, error)
+    // InitSite creates the target's top-level config file if it doesn't
+    // already exist (docs.json, sidebars.js, astro config, mkdocs.yml...).
+    InitSite(outDir, name string) error
+    // UpdateNavigation rewrites the target's navigation tree from the
+    // hierarchical section tree built by buildHierarchy.
+    UpdateNavigation(outDir, name string, tree []Section) error
+    // AssetPath returns the path an image should be referenced by from
+    // within a rendered page's Markdown.
+    AssetPath(img ImageRef) string
+}
+
+// sectionEnhancer is the subset of ai.Enhancer a Renderer needs while
+// rendering a section's body (captions, fallback summaries, component
+// annotation). Declared locally so renderer.go doesn't import internal/ai.
+type sectionEnhancer interface {
+    SuggestComponents(ctx context.Context, text string, allow []string, mode string) (string, error)
+    Summarize(ctx context.Context, text string, maxTokens int) (string, error)
+    Caption(ctx context.Context, imagePath string) (string, error)
+    ModelID() string
+}
+
+// NewRenderer resolves a Renderer by --format name, defaulting to Mintlify
+// (the original hard-coded behavior) for an unrecognized or empty format.
+// aiSem caps how many of the renderer's AI calls (captions, summaries,
+// component suggestions) may be in flight at once, independent of how many
+// sections convert.Run renders concurrently.
+func NewRenderer(format string, enhancer sectionEnhancer, siteAllow []string, aiSem semaphore) Renderer {
+    switch strings.ToLower(format) {
+    case "docusaurus":
+        return &docusaurusRenderer{enhancer: enhancer, siteAllow: siteAllow, aiSem: aiSem}
+    case "starlight":
+        return &starlightRenderer{enhancer: enhancer, siteAllow: siteAllow, aiSem: aiSem}
+    case "mkdocs":
+        return &mkdocsRenderer{enhancer: enhancer, siteAllow: siteAllow, aiSem: aiSem}
+    default:
+        return &mintlifyRenderer{enhancer: enhancer, siteAllow: siteAllow, aiSem: aiSem}
+    }
+}
+
+// writeYAMLFrontMatter serializes body under outDir/slug+ext with a YAML
+// front matter block built from fm, going through the frontmatter package so
+// every renderer and the convert-frontmatter round-trip share one
+// serializer instead of each hand-formatting its own "---" block.
+func writeYAMLFrontMatter(outDir, slug, ext string, fm map[string]any, body string) (string, error) {
+    page, err := frontmatter.Serialize(frontmatter.YAML, fm, []byte(body))
+    if err != nil {
+        return "", err
+    }
+    file := filepath.Join(outDir, slug+ext)
+    if err := os.WriteFile(file, page, 0o644); err != nil {
+        return "", err
+    }
+    return file, nil
+}
+
+// mergeParams copies s.Params() into fm, letting a _section override file
+// add arbitrary extra front-matter keys (e.g. description, icon) alongside
+// the ones a renderer sets itself.
+func mergeParams(fm map[string]any, s Section) map[string]any {
+    for k, v := range s.Params() {
+        fm[k] = v
+    }
+    return fm
+}
+
+// renderBody builds the Markdown body shared by every renderer: page text
+// (with code fences stripped) interleaved with that page's images, each
+// captioned via the enhancer when available, followed by one pass of
+// component suggestion over the whole body. Image captions run concurrently
+// across aiSem's capacity; the body is still assembled in source (page,
+// then image) order, so output is deterministic regardless of how the
+// caption calls interleave.
+func renderBody(ctx context.Context, outDir, title string, pageTexts []PageText, images []ImageRef, siteAllow []string, enhancer sectionEnhancer, assetPath func(ImageRef) string, aiSem semaphore) string {
+    captions := captionImagesParallel(ctx, outDir, title, pageTexts, images, enhancer, aiSem)
+
+    var b strings.Builder
+    for _, pt := range pageTexts {
+        if t := strings.TrimSpace(pt.Text); t != "" {
+            b.WriteString(stripMarkdownCodeFences(t))
+            b.WriteString("\n\n")
+        }
+        for i, img := range images {
+            if img.Page != pt.Page {
+                continue
+            }
+            rel := assetPath(img)
+            b.WriteString(fmt.Sprintf("![%s](%s)\n\n", escapeQuotes(captions[i]), rel))
+        }
+    }
+
+    content := b.String()
+    if enhancer != nil {
+        if aiSem != nil {
+            if err := aiSem.Acquire(ctx); err != nil {
+                return content
+            }
+            defer aiSem.Release()
+        }
+        if updated, err := enhancer.SuggestComponents(ctx, content, siteAllow, "conservative"); err == nil && updated != "" {
+            content = stripMarkdownCodeFences(updated)
+        }
+    }
+    return content
+}
+
+// captionImagesParallel captions every image concurrently, bounded by
+// aiSem, and returns the captions indexed the same as images so callers can
+// reassemble the body in source order.
+func captionImagesParallel(ctx context.Context, outDir, title string, pageTexts []PageText, images []ImageRef, enhancer sectionEnhancer, aiSem semaphore) []string {
+    textByPage := make(map[int]string, len(pageTexts))
+    for _, pt := range pageTexts {
+        textByPage[pt.Page] = pt.Text
+    }
+
+    captions := make([]string, len(images))
+    var wg sync.WaitGroup
+    for i, img := range images {
+        wg.Add(1)
+        go func(i int, img ImageRef) {
+            defer wg.Done()
+            if aiSem != nil {
+                if err := aiSem.Acquire(ctx); err != nil {
+                    captions[i] = "Image"
+                    return
+                }
+                defer aiSem.Release()
+            }
+            captions[i] = captionImage(ctx, outDir, img, title, textByPage[img.Page], enhancer)
+        }(i, img)
+    }
+    wg.Wait()
+    return captions
+}
+
+// captionImage returns alt text for img: a multimodal caption when the
+// enhancer supports it, falling back to a text-only summary of the
+// surrounding page content, or "Image" if neither succeeds.
+func captionImage(ctx context.Context, outDir string, img ImageRef, title, pageText string, enhancer sectionEnhancer) string {
+    if enhancer == nil {
+        return "Image"
+    }
+    full := filepath.Join(outDir, "images", img.Name)
+    if cap, err := enhancer.Caption(ctx, full); err == nil && strings.TrimSpace(cap) != "" {
+        return strings.TrimSpace(cap)
+    }
+    snippet := title
+    if len(pageText) > 0 {
+        if len(pageText) > 280 {
+            snippet = pageText[:280]
+        } else {
+            snippet = pageText
+        }
+    }
+    if sum, err := enhancer.Summarize(ctx, "Generate a short descriptive alt text for an image in a section titled '"+title+"' with this context: \n"+snippet, 30); err == nil && strings.TrimSpace(sum) != "" {
+        return strings.TrimSpace(sum)
+    }
+    return "Image"
+}
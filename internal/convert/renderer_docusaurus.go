@@ -0,0 +1,82 @@
+package convert
+
+import (
+    "context"
+    "encoding/json"
+    "os"
+    "path/filepath"
+)
+
+// docusaurusRenderer writes plain Markdown under docs/ with front matter
+// Docusaurus understands, and maintains a generated sidebars.js.
+type docusaurusRenderer struct {
+    enhancer  sectionEnhancer
+    siteAllow []string
+    aiSem     semaphore
+}
+
+func (r *docusaurusRenderer) AssetPath(img ImageRef) string {
+    return "/img/" + filepath.ToSlash(img.Name)
+}
+
+func (r *docusaurusRenderer) WriteSection(ctx context.Context, outDir string, s Section, pageTexts []PageText, images []ImageRef) (string, error) {
+    docsDir := filepath.Join(outDir, "docs")
+    if err := os.MkdirAll(docsDir, 0o755); err != nil {
+        return "", err
+    }
+    body := renderBody(ctx, outDir, s.Title(), pageTexts, images, r.siteAllow, r.enhancer, r.AssetPath, r.aiSem)
+    content := "# " + s.Title() + "\n\n" + body
+    fm := mergeParams(map[string]any{"sidebar_position": sidebarPosition(s), "title": s.Title()}, s)
+    return writeYAMLFrontMatter(docsDir, s.Slug(), ".md", fm, content)
+}
+
+func sidebarPosition(s Section) int {
+    if s.Depth() <= 0 {
+        return 1
+    }
+    return s.Depth()
+}
+
+func (r *docusaurusRenderer) InitSite(outDir, name string) error {
+    path := filepath.Join(outDir, "sidebars.js")
+    if _, err := os.Stat(path); err == nil {
+        return nil
+    }
+    const stub = "// Auto-generated by pdf2docs. Regenerated on every run; edit via --format docusaurus re-runs, not by hand.\nmodule.exports = {\n  docsSidebar: [],\n};\n"
+    return os.WriteFile(path, []byte(stub), 0o644)
+}
+
+func (r *docusaurusRenderer) UpdateNavigation(outDir, name string, tree []Section) error {
+    items := docusaurusSidebarItems(tree)
+    b, err := json.MarshalIndent(items, "  ", "  ")
+    if err != nil {
+        return err
+    }
+    content := "// Auto-generated by pdf2docs. Do not edit by hand.\nmodule.exports = {\n  docsSidebar: " + string(b) + ",\n};\n"
+    return os.WriteFile(filepath.Join(outDir, "sidebars.js"), []byte(content), 0o644)
+}
+
+// docusaurusSidebarItems converts a Section tree into Docusaurus sidebar
+// items: a leaf is its doc id, a parent becomes a "category" whose own page
+// (if it has one - a content-less groupSection doesn't) is the first of its
+// "items", ahead of its children.
+func docusaurusSidebarItems(tree []Section) []interface{} {
+    var out []interface{}
+    for _, s := range tree {
+        if len(s.Children()) == 0 {
+            out = append(out, s.Slug())
+            continue
+        }
+        var items []interface{}
+        if s.Slug() != "" {
+            items = append(items, s.Slug())
+        }
+        items = append(items, docusaurusSidebarItems(s.Children())...)
+        out = append(out, map[string]interface{}{
+            "type":  "category",
+            "label": s.Number() + " " + s.Title(),
+            "items": items,
+        })
+    }
+    return out
+}
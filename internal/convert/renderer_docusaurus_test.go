@@ -0,0 +1,43 @@
+package convert
+
+import "testing"
+
+func TestDocusaurusSidebarItemsIncludesParentOwnSlug(t *testing.T) {
+    child := NewPDFSection("1.1", "Child", 1, 1, 2, "child")
+    parent := NewPDFSection("1", "Parent", 1, 2, 1, "parent")
+    parent.AppendChild(child)
+
+    items := docusaurusSidebarItems([]Section{parent})
+    if len(items) != 1 {
+        t.Fatalf("docusaurusSidebarItems() = %v, want a single top-level category", items)
+    }
+    category, ok := items[0].(map[string]interface{})
+    if !ok {
+        t.Fatalf("items[0] = %T, want a category map", items[0])
+    }
+    catItems, _ := category["items"].([]interface{})
+    if len(catItems) != 2 || catItems[0] != "parent" || catItems[1] != "child" {
+        t.Fatalf("category items = %v, want [parent child]", catItems)
+    }
+}
+
+func TestDocusaurusSidebarItemsOmitsOwnSlugForContentLessGroup(t *testing.T) {
+    child := NewPDFSection("1.1", "Child", 1, 1, 2, "child")
+    group := NewGroupSection("1", "Group", 1)
+    group.AppendChild(child)
+
+    items := docusaurusSidebarItems([]Section{group})
+    category := items[0].(map[string]interface{})
+    catItems, _ := category["items"].([]interface{})
+    if len(catItems) != 1 || catItems[0] != "child" {
+        t.Fatalf("category items = %v, want [child] with no blank slug for the group itself", catItems)
+    }
+}
+
+func TestDocusaurusSidebarItemsLeafIsBareSlug(t *testing.T) {
+    leaf := NewPDFSection("2", "Leaf", 1, 1, 1, "leaf")
+    items := docusaurusSidebarItems([]Section{leaf})
+    if len(items) != 1 || items[0] != "leaf" {
+        t.Fatalf("docusaurusSidebarItems() = %v, want [\"leaf\"]", items)
+    }
+}
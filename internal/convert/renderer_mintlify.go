@@ -0,0 +1,160 @@
+package convert
+
+import (
+    "context"
+    "encoding/json"
+    "os"
+    "path/filepath"
+    "regexp"
+)
+
+// mintlifyRenderer writes Mintlify-flavored MDX with a docs.json tabs/groups
+// navigation tree. This is the original, default output format.
+type mintlifyRenderer struct {
+    enhancer  sectionEnhancer
+    siteAllow []string
+    aiSem     semaphore
+}
+
+func (r *mintlifyRenderer) AssetPath(img ImageRef) string {
+    return "./" + filepath.ToSlash(filepath.Join("images", img.Name))
+}
+
+func (r *mintlifyRenderer) WriteSection(ctx context.Context, outDir string, s Section, pageTexts []PageText, images []ImageRef) (string, error) {
+    body := renderBody(ctx, outDir, s.Title(), pageTexts, images, r.siteAllow, r.enhancer, r.AssetPath, r.aiSem)
+    content := "# " + s.Title() + "\n\n" + body
+    fm := mergeParams(map[string]any{"title": s.Title()}, s)
+    return writeYAMLFrontMatter(outDir, s.Slug(), ".mdx", fm, content)
+}
+
+type docsJSON struct {
+    Schema     string                 `json:"$schema"`
+    Theme      string                 `json:"theme"`
+    Name       string                 `json:"name"`
+    Colors     map[string]string      `json:"colors,omitempty"`
+    Favicon    string                 `json:"favicon,omitempty"`
+    Navigation map[string]interface{} `json:"navigation"`
+    Logo       map[string]string      `json:"logo,omitempty"`
+    Navbar     map[string]interface{} `json:"navbar,omitempty"`
+    Contextual map[string]interface{} `json:"contextual,omitempty"`
+    Footer     map[string]interface{} `json:"footer,omitempty"`
+}
+
+func (r *mintlifyRenderer) InitSite(outDir, name string) error {
+    path := filepath.Join(outDir, "docs.json")
+    if _, err := os.Stat(path); err == nil {
+        return nil
+    }
+    return initializeDocsJSON(path, name)
+}
+
+func initializeDocsJSON(path string, siteName string) error {
+    if siteName == "" {
+        siteName = "Documentation"
+    }
+
+    cfg := docsJSON{
+        Schema: "https://mintlify.com/docs.json",
+        Theme:  "mint",
+        Name:   siteName,
+        Colors: map[string]string{
+            "primary": "#16A34A",
+            "light":   "#07C983",
+            "dark":    "#15803D",
+        },
+        Navigation: map[string]interface{}{
+            "tabs": []map[string]interface{}{},
+        },
+    }
+
+    out, err := json.MarshalIndent(cfg, "", "  ")
+    if err != nil {
+        return err
+    }
+    return os.WriteFile(path, out, 0o644)
+}
+
+func (r *mintlifyRenderer) UpdateNavigation(outDir, name string, tree []Section) error {
+    return updateDocsJSON(filepath.Join(outDir, "docs.json"), name, tree)
+}
+
+func updateDocsJSON(path string, siteName string, tree []Section) error {
+    b, err := os.ReadFile(path)
+    if err != nil {
+        return err
+    }
+    var cfg docsJSON
+    if err := json.Unmarshal(b, &cfg); err != nil {
+        return err
+    }
+    if siteName != "" {
+        cfg.Name = siteName
+    }
+
+    // Build nested page list from the section tree.
+    pages := []interface{}{"index"} // Always include index first
+    for _, s := range tree {
+        if len(s.Children()) == 0 {
+            pages = append(pages, s.Slug())
+            continue
+        }
+        pages = append(pages, map[string]interface{}{
+            "group": groupLabel(s),
+            "pages": buildPagesRecursive(s),
+        })
+    }
+
+    // Create single Documentation tab with single Manual group
+    tabs := []map[string]interface{}{
+        {
+            "tab": "Documentation",
+            "groups": []map[string]interface{}{
+                {
+                    "group": "Manual",
+                    "pages": pages,
+                },
+            },
+        },
+    }
+
+    cfg.Navigation = map[string]interface{}{
+        "tabs": tabs,
+    }
+
+    out, err := json.MarshalIndent(cfg, "", "  ")
+    if err != nil {
+        return err
+    }
+    return os.WriteFile(path, out, 0o644)
+}
+
+// buildPagesRecursive converts a Section tree into a Mintlify-compatible pages array.
+// Each entry is either a string slug (leaf page) or a nested group object.
+func buildPagesRecursive(s Section) []interface{} {
+    var pages []interface{}
+    // include the section's own page first, unless it's content-less (e.g.
+    // a groupSection spliced in by --include) and has no slug of its own
+    if s.Slug() != "" {
+        pages = append(pages, s.Slug())
+    }
+    for _, c := range s.Children() {
+        if len(c.Children()) == 0 {
+            pages = append(pages, c.Slug())
+            continue
+        }
+        pages = append(pages, map[string]interface{}{
+            "group": groupLabel(c),
+            "pages": buildPagesRecursive(c),
+        })
+    }
+    return pages
+}
+
+func groupLabel(s Section) string {
+    // If top-level alpha-only numbering, prefix with "Appendix" for clarity.
+    alphaOnly := regexp.MustCompile(`^[A-Z]+$`)
+    if s.Depth() == 1 && alphaOnly.MatchString(s.Number()) {
+        return "Appendix " + s.Number() + " " + s.Title()
+    }
+    return s.Number() + " " + s.Title()
+}
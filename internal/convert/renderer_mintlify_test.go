@@ -0,0 +1,30 @@
+package convert
+
+import "testing"
+
+func TestBuildPagesRecursiveOmitsContentLessGroupSlug(t *testing.T) {
+    group := NewGroupSection("1", "Part One", 1)
+    child := NewPDFSection("1.1", "Child", 1, 2, 2, "child")
+    group.AppendChild(child)
+
+    pages := buildPagesRecursive(group)
+    for _, p := range pages {
+        if s, ok := p.(string); ok && s == "" {
+            t.Fatalf("buildPagesRecursive() = %v, contains a blank slug entry for a content-less group section", pages)
+        }
+    }
+    if len(pages) != 1 || pages[0] != "child" {
+        t.Fatalf("buildPagesRecursive() = %v, want only the child's slug", pages)
+    }
+}
+
+func TestBuildPagesRecursiveIncludesOwnSlugForContentSection(t *testing.T) {
+    parent := NewPDFSection("1", "Parent", 1, 5, 1, "parent")
+    child := NewPDFSection("1.1", "Child", 1, 2, 2, "child")
+    parent.AppendChild(child)
+
+    pages := buildPagesRecursive(parent)
+    if len(pages) == 0 || pages[0] != "parent" {
+        t.Fatalf("buildPagesRecursive() = %v, want own slug first", pages)
+    }
+}
@@ -0,0 +1,83 @@
+package convert
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// mkdocsRenderer writes plain Markdown under docs/ and maintains the `nav`
+// block of mkdocs.yml.
+type mkdocsRenderer struct {
+    enhancer  sectionEnhancer
+    siteAllow []string
+    aiSem     semaphore
+}
+
+func (r *mkdocsRenderer) AssetPath(img ImageRef) string {
+    return filepath.ToSlash(filepath.Join("images", img.Name))
+}
+
+func (r *mkdocsRenderer) WriteSection(ctx context.Context, outDir string, s Section, pageTexts []PageText, images []ImageRef) (string, error) {
+    docsDir := filepath.Join(outDir, "docs")
+    if err := os.MkdirAll(docsDir, 0o755); err != nil {
+        return "", err
+    }
+    body := renderBody(ctx, outDir, s.Title(), pageTexts, images, r.siteAllow, r.enhancer, r.AssetPath, r.aiSem)
+    content := "# " + s.Title() + "\n\n" + body
+    file := filepath.Join(docsDir, s.Slug()+".md")
+    if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+        return "", err
+    }
+    return file, nil
+}
+
+func (r *mkdocsRenderer) InitSite(outDir, name string) error {
+    path := filepath.Join(outDir, "mkdocs.yml")
+    if _, err := os.Stat(path); err == nil {
+        return nil
+    }
+    siteName := name
+    if siteName == "" {
+        siteName = "Documentation"
+    }
+    stub := fmt.Sprintf("site_name: %q\nnav:\n  - Home: index.md\n", siteName)
+    return os.WriteFile(path, []byte(stub), 0o644)
+}
+
+func (r *mkdocsRenderer) UpdateNavigation(outDir, name string, tree []Section) error {
+    siteName := name
+    if siteName == "" {
+        siteName = "Documentation"
+    }
+    var b strings.Builder
+    b.WriteString(fmt.Sprintf("site_name: %q\n", siteName))
+    b.WriteString("nav:\n")
+    b.WriteString("  - Home: index.md\n")
+    b.WriteString(mkdocsNavYAML(tree, 1))
+    return os.WriteFile(filepath.Join(outDir, "mkdocs.yml"), []byte(b.String()), 0o644)
+}
+
+// mkdocsNavYAML renders a Section tree as mkdocs.yml's `nav` list: a leaf is
+// "Title: slug.md", a parent becomes a named sub-list whose own page (if it
+// has one - a content-less groupSection doesn't) is the first entry, ahead
+// of its children.
+func mkdocsNavYAML(tree []Section, indent int) string {
+    pad := strings.Repeat("  ", indent)
+    childPad := strings.Repeat("  ", indent+1)
+    var out strings.Builder
+    for _, s := range tree {
+        if len(s.Children()) == 0 {
+            out.WriteString(fmt.Sprintf("%s- %q: %s.md\n", pad, s.Title(), s.Slug()))
+            continue
+        }
+        out.WriteString(fmt.Sprintf("%s- %q:\n", pad, s.Title()))
+        if s.Slug() != "" {
+            out.WriteString(fmt.Sprintf("%s- %q: %s.md\n", childPad, s.Title(), s.Slug()))
+        }
+        out.WriteString(mkdocsNavYAML(s.Children(), indent+1))
+    }
+    return out.String()
+}
@@ -0,0 +1,49 @@
+package convert
+
+import (
+    "strings"
+    "testing"
+
+    "gopkg.in/yaml.v3"
+)
+
+func TestMkdocsNavYAMLQuotesColonInTitle(t *testing.T) {
+    tree := []Section{NewPDFSection("3", "Chapter 3: Setup", 1, 2, 1, "chapter-3")}
+    nav := mkdocsNavYAML(tree, 1)
+
+    var decoded []map[string]string
+    if err := yaml.Unmarshal([]byte(nav), &decoded); err != nil {
+        t.Fatalf("mkdocsNavYAML() produced invalid YAML: %v\n%s", err, nav)
+    }
+    if len(decoded) != 1 || decoded[0]["Chapter 3: Setup"] != "chapter-3.md" {
+        t.Fatalf("decoded nav = %v, want {%q: %q}", decoded, "Chapter 3: Setup", "chapter-3.md")
+    }
+}
+
+func TestMkdocsNavYAMLNestsChildren(t *testing.T) {
+    child := NewPDFSection("1.1", "Child", 1, 1, 2, "child")
+    parent := NewPDFSection("1", "Parent", 1, 2, 1, "parent")
+    parent.AppendChild(child)
+
+    nav := mkdocsNavYAML([]Section{parent}, 1)
+    if !strings.Contains(nav, "parent.md") {
+        t.Fatalf("mkdocsNavYAML() = %q, want it to still include the parent's own page alongside its children", nav)
+    }
+    if !strings.Contains(nav, "child.md") {
+        t.Fatalf("mkdocsNavYAML() = %q, want it to include the nested child entry", nav)
+    }
+}
+
+func TestMkdocsNavYAMLOmitsOwnPageForContentLessGroup(t *testing.T) {
+    child := NewPDFSection("1.1", "Child", 1, 1, 2, "child")
+    group := NewGroupSection("1", "Group", 1)
+    group.AppendChild(child)
+
+    nav := mkdocsNavYAML([]Section{group}, 1)
+    if strings.Contains(nav, ".md\n") && strings.Count(nav, ".md") != 1 {
+        t.Fatalf("mkdocsNavYAML() = %q, want exactly one .md entry (the child's), no blank slug for the group itself", nav)
+    }
+    if !strings.Contains(nav, "child.md") {
+        t.Fatalf("mkdocsNavYAML() = %q, want it to include the nested child entry", nav)
+    }
+}
@@ -0,0 +1,105 @@
+package convert
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "path/filepath"
+)
+
+// starlightRenderer writes Markdown under src/content/docs/ with the front
+// matter Astro's Starlight content collection expects, and maintains a
+// generated sidebar in the Astro config.
+type starlightRenderer struct {
+    enhancer  sectionEnhancer
+    siteAllow []string
+    aiSem     semaphore
+}
+
+func (r *starlightRenderer) AssetPath(img ImageRef) string {
+    return "/images/" + filepath.ToSlash(img.Name)
+}
+
+func (r *starlightRenderer) WriteSection(ctx context.Context, outDir string, s Section, pageTexts []PageText, images []ImageRef) (string, error) {
+    contentDir := filepath.Join(outDir, "src", "content", "docs")
+    if err := os.MkdirAll(contentDir, 0o755); err != nil {
+        return "", err
+    }
+    body := renderBody(ctx, outDir, s.Title(), pageTexts, images, r.siteAllow, r.enhancer, r.AssetPath, r.aiSem)
+    fm := mergeParams(map[string]any{"title": s.Title()}, s)
+    return writeYAMLFrontMatter(contentDir, s.Slug(), ".md", fm, body)
+}
+
+func (r *starlightRenderer) InitSite(outDir, name string) error {
+    path := filepath.Join(outDir, "astro.config.mjs")
+    if _, err := os.Stat(path); err == nil {
+        return nil
+    }
+    siteName := name
+    if siteName == "" {
+        siteName = "Documentation"
+    }
+    stub := fmt.Sprintf(`// Auto-generated by pdf2docs. Sidebar entries are regenerated on every run.
+import { defineConfig } from 'astro/config';
+import starlight from '@astrojs/starlight';
+
+export default defineConfig({
+  integrations: [
+    starlight({
+      title: %q,
+      sidebar: [],
+    }),
+  ],
+});
+`, siteName)
+    return os.WriteFile(path, []byte(stub), 0o644)
+}
+
+func (r *starlightRenderer) UpdateNavigation(outDir, name string, tree []Section) error {
+    sidebar := starlightSidebarYAML(tree, 1)
+    siteName := name
+    if siteName == "" {
+        siteName = "Documentation"
+    }
+    stub := fmt.Sprintf(`// Auto-generated by pdf2docs. Do not edit by hand.
+import { defineConfig } from 'astro/config';
+import starlight from '@astrojs/starlight';
+
+export default defineConfig({
+  integrations: [
+    starlight({
+      title: %q,
+      sidebar: [
+%s      ],
+    }),
+  ],
+});
+`, siteName, sidebar)
+    return os.WriteFile(filepath.Join(outDir, "astro.config.mjs"), []byte(stub), 0o644)
+}
+
+// starlightSidebarYAML renders a Section tree as Starlight's JS sidebar
+// array literal (label/link for leaves, label/items for groups). A group's
+// own page (if it has one - a content-less groupSection doesn't) is the
+// first of its "items", ahead of its children.
+func starlightSidebarYAML(tree []Section, indent int) string {
+    pad := ""
+    for i := 0; i < indent; i++ {
+        pad += "  "
+    }
+    childPad := pad + "  "
+    var out string
+    for _, s := range tree {
+        if len(s.Children()) == 0 {
+            out += fmt.Sprintf("%s{ label: %q, link: '/%s/' },\n", pad, s.Title(), s.Slug())
+            continue
+        }
+        var ownPage string
+        if s.Slug() != "" {
+            ownPage = fmt.Sprintf("%s{ label: %q, link: '/%s/' },\n", childPad, s.Title(), s.Slug())
+        }
+        out += fmt.Sprintf("%s{\n%s  label: %q,\n%s  items: [\n%s%s%s  ],\n%s},\n",
+            pad, pad, s.Title(), pad, ownPage, starlightSidebarYAML(s.Children(), indent+2), pad, pad)
+    }
+    return out
+}
@@ -0,0 +1,42 @@
+package convert
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestStarlightSidebarYAMLIncludesParentOwnLink(t *testing.T) {
+    child := NewPDFSection("1.1", "Child", 1, 1, 2, "child")
+    parent := NewPDFSection("1", "Parent", 1, 2, 1, "parent")
+    parent.AppendChild(child)
+
+    sidebar := starlightSidebarYAML([]Section{parent}, 1)
+    if !strings.Contains(sidebar, "link: '/parent/'") {
+        t.Fatalf("starlightSidebarYAML() = %q, want a link to the parent's own page alongside its children", sidebar)
+    }
+    if !strings.Contains(sidebar, "link: '/child/'") {
+        t.Fatalf("starlightSidebarYAML() = %q, want it to include the nested child entry", sidebar)
+    }
+}
+
+func TestStarlightSidebarYAMLOmitsOwnLinkForContentLessGroup(t *testing.T) {
+    child := NewPDFSection("1.1", "Child", 1, 1, 2, "child")
+    group := NewGroupSection("1", "Group", 1)
+    group.AppendChild(child)
+
+    sidebar := starlightSidebarYAML([]Section{group}, 1)
+    if strings.Contains(sidebar, "link: '//'") {
+        t.Fatalf("starlightSidebarYAML() = %q, want no blank-slug link for the content-less group itself", sidebar)
+    }
+    if !strings.Contains(sidebar, "link: '/child/'") {
+        t.Fatalf("starlightSidebarYAML() = %q, want it to include the nested child entry", sidebar)
+    }
+}
+
+func TestStarlightSidebarYAMLLeafIsLabelLink(t *testing.T) {
+    leaf := NewPDFSection("2", "Leaf", 1, 1, 1, "leaf")
+    sidebar := starlightSidebarYAML([]Section{leaf}, 1)
+    if !strings.Contains(sidebar, `label: "Leaf"`) || !strings.Contains(sidebar, "link: '/leaf/'") {
+        t.Fatalf("starlightSidebarYAML() = %q, want a label/link entry for the leaf", sidebar)
+    }
+}
@@ -0,0 +1,159 @@
+package convert
+
+import (
+    "context"
+    "encoding/json"
+)
+
+// Section is one node in the generated documentation tree. The PDF's own
+// table of contents (or the page/heading fallback) produces pdfSection
+// nodes; a --include manifest can splice fileSection (an existing
+// Markdown/MDX file copied through as-is) and groupSection (a pure
+// navigation heading with no page of its own) nodes in alongside them.
+type Section interface {
+    Number() string
+    // Title returns the TitleOverride from a _section override file, if one
+    // was applied, falling back to the auto-derived title otherwise.
+    Title() string
+    Start() int
+    End() int
+    Depth() int
+    Slug() string
+    Children() []Section
+    // TreePath returns the canonical, slash-joined sectiontree path this
+    // section should be inserted at when building navigation hierarchy.
+    // Distinct from Number: an appendix's path is prefixed "appendix/" so
+    // it never collides with an unrelated top-level section sharing the
+    // same letter (see buildSections' entryPath).
+    TreePath() string
+    // Name is a stable lookup key, independent of Slug, defaulting to Slug
+    // unless a _section override file sets one explicitly.
+    Name() string
+    // Params holds arbitrary key/value pairs from a _section override
+    // file's `params` map, merged into the rendered page's front matter.
+    Params() map[string]any
+    // Weight orders sections within their parent for navigation and the
+    // generated index, lowest first; zero (the default) preserves the
+    // order Run otherwise derived.
+    Weight() int
+    // Draft sections are dropped from Run before rendering, navigation, and
+    // the generated index.
+    Draft() bool
+
+    AppendChild(child Section)
+    SetChildren(children []Section)
+    SetSlug(slug string)
+    SetDepth(depth int)
+    SetTreePath(path string)
+    SetName(name string)
+    SetTitleOverride(title string)
+    SetParams(params map[string]any)
+    SetWeight(weight int)
+    SetDraft(draft bool)
+
+    // Render writes this section's own page to outDir, if it has one.
+    Render(ctx context.Context, outDir string, renderer Renderer) error
+}
+
+// sectionJSON is the wire format shared by every Section implementation,
+// matching the original flat Section struct's JSON shape.
+type sectionJSON struct {
+    Number   string    `json:"number"`
+    Title    string    `json:"title"`
+    Start    int       `json:"start_page"`
+    End      int       `json:"end_page"`
+    Depth    int       `json:"depth"`
+    Slug     string    `json:"slug"`
+    Children []Section `json:"children,omitempty"`
+}
+
+// pdfSection is a Section derived from the PDF's table of contents or its
+// page/heading fallback split.
+type pdfSection struct {
+    number        string
+    title         string
+    titleOverride string
+    start         int
+    end           int
+    depth         int
+    slug          string
+    treePath      string
+    name          string
+    params        map[string]any
+    weight        int
+    draft         bool
+    children      []Section
+}
+
+// NewPDFSection constructs a pdfSection covering the page range [start, end].
+func NewPDFSection(number, title string, start, end, depth int, slug string) *pdfSection {
+    return &pdfSection{number: number, title: title, start: start, end: end, depth: depth, slug: slug}
+}
+
+func (s *pdfSection) Number() string { return s.number }
+
+// Title returns titleOverride, set by a _section override file, if present.
+func (s *pdfSection) Title() string {
+    if s.titleOverride != "" {
+        return s.titleOverride
+    }
+    return s.title
+}
+func (s *pdfSection) Start() int             { return s.start }
+func (s *pdfSection) End() int               { return s.end }
+func (s *pdfSection) Depth() int             { return s.depth }
+func (s *pdfSection) Slug() string           { return s.slug }
+func (s *pdfSection) Children() []Section    { return s.children }
+func (s *pdfSection) Name() string           { return s.name }
+func (s *pdfSection) Params() map[string]any { return s.params }
+func (s *pdfSection) Weight() int            { return s.weight }
+func (s *pdfSection) Draft() bool            { return s.draft }
+
+// TreePath defaults to the dotted Number split into path segments, unless
+// buildSections set an explicit one (an appendix's "appendix/..." prefix).
+func (s *pdfSection) TreePath() string {
+    if s.treePath != "" {
+        return s.treePath
+    }
+    return numberPath(s.number)
+}
+
+func (s *pdfSection) AppendChild(child Section)       { s.children = append(s.children, child) }
+func (s *pdfSection) SetChildren(children []Section)  { s.children = children }
+func (s *pdfSection) SetTreePath(path string)         { s.treePath = path }
+func (s *pdfSection) SetSlug(slug string)             { s.slug = slug }
+func (s *pdfSection) SetDepth(depth int)              { s.depth = depth }
+func (s *pdfSection) SetName(name string)             { s.name = name }
+func (s *pdfSection) SetTitleOverride(title string)   { s.titleOverride = title }
+func (s *pdfSection) SetParams(params map[string]any) { s.params = params }
+func (s *pdfSection) SetWeight(weight int)            { s.weight = weight }
+func (s *pdfSection) SetDraft(draft bool)             { s.draft = draft }
+
+// Render is a no-op: pdfSection pages are written directly by Run via
+// renderer.WriteSection, since only Run has the page text and images a
+// PDF-derived section needs.
+func (s *pdfSection) Render(ctx context.Context, outDir string, renderer Renderer) error {
+    return nil
+}
+
+func (s *pdfSection) MarshalJSON() ([]byte, error) {
+    return json.Marshal(sectionJSON{
+        Number: s.number, Title: s.title, Start: s.start, End: s.end,
+        Depth: s.depth, Slug: s.slug, Children: s.children,
+    })
+}
+
+// renderIncluded walks a section tree calling Render on every node. It's
+// safe to call unconditionally after splicing --include content into an
+// already-rendered pdfSection tree, since pdfSection.Render no-ops.
+func renderIncluded(ctx context.Context, outDir string, renderer Renderer, nodes []Section) error {
+    for _, n := range nodes {
+        if err := n.Render(ctx, outDir, renderer); err != nil {
+            return err
+        }
+        if err := renderIncluded(ctx, outDir, renderer, n.Children()); err != nil {
+            return err
+        }
+    }
+    return nil
+}
@@ -0,0 +1,104 @@
+package convert
+
+import (
+    "context"
+    "encoding/json"
+    "io"
+    "os"
+    "path/filepath"
+)
+
+// fileSection adopts an existing Markdown/MDX file into the tree via
+// Config.Include, copying its content through unchanged instead of
+// rendering PDF page text. It is always written at outDir's root; renderers
+// that nest pages under a subdirectory (Docusaurus, MkDocs, Starlight) are
+// not yet supported as --include targets.
+type fileSection struct {
+    sourcePath    string
+    number        string
+    title         string
+    titleOverride string
+    depth         int
+    slug          string
+    treePath      string
+    name          string
+    params        map[string]any
+    weight        int
+    draft         bool
+    children      []Section
+}
+
+// NewFileSection constructs a fileSection that copies sourcePath into
+// outDir/<slug><ext> when rendered.
+func NewFileSection(sourcePath, number, title, slug string) *fileSection {
+    return &fileSection{sourcePath: sourcePath, number: number, title: title, slug: slug, depth: 1}
+}
+
+func (s *fileSection) Number() string { return s.number }
+
+// Title returns titleOverride, set by a _section override file, if present.
+func (s *fileSection) Title() string {
+    if s.titleOverride != "" {
+        return s.titleOverride
+    }
+    return s.title
+}
+func (s *fileSection) Start() int             { return 0 }
+func (s *fileSection) End() int               { return 0 }
+func (s *fileSection) Depth() int             { return s.depth }
+func (s *fileSection) Slug() string           { return s.slug }
+func (s *fileSection) Children() []Section    { return s.children }
+func (s *fileSection) Name() string           { return s.name }
+func (s *fileSection) Params() map[string]any { return s.params }
+func (s *fileSection) Weight() int            { return s.weight }
+func (s *fileSection) Draft() bool            { return s.draft }
+
+// TreePath defaults to the dotted Number split into path segments; spliced
+// --include entries use an auto-generated "include-N" Number, which is
+// already unique, so there's no appendix-style override to make.
+func (s *fileSection) TreePath() string {
+    if s.treePath != "" {
+        return s.treePath
+    }
+    return numberPath(s.number)
+}
+
+func (s *fileSection) AppendChild(child Section)       { s.children = append(s.children, child) }
+func (s *fileSection) SetChildren(children []Section)  { s.children = children }
+func (s *fileSection) SetTreePath(path string)         { s.treePath = path }
+func (s *fileSection) SetSlug(slug string)             { s.slug = slug }
+func (s *fileSection) SetDepth(depth int)              { s.depth = depth }
+func (s *fileSection) SetName(name string)             { s.name = name }
+func (s *fileSection) SetTitleOverride(title string)   { s.titleOverride = title }
+func (s *fileSection) SetParams(params map[string]any) { s.params = params }
+func (s *fileSection) SetWeight(weight int)            { s.weight = weight }
+func (s *fileSection) SetDraft(draft bool)             { s.draft = draft }
+
+// Render copies the adopted file's contents through to outDir verbatim,
+// preserving its original extension.
+func (s *fileSection) Render(ctx context.Context, outDir string, renderer Renderer) error {
+    in, err := os.Open(s.sourcePath)
+    if err != nil {
+        return err
+    }
+    defer in.Close()
+
+    ext := filepath.Ext(s.sourcePath)
+    if ext == "" {
+        ext = ".md"
+    }
+    out, err := os.Create(filepath.Join(outDir, s.slug+ext))
+    if err != nil {
+        return err
+    }
+    defer out.Close()
+
+    _, err = io.Copy(out, in)
+    return err
+}
+
+func (s *fileSection) MarshalJSON() ([]byte, error) {
+    return json.Marshal(sectionJSON{
+        Number: s.number, Title: s.title, Depth: s.depth, Slug: s.slug, Children: s.children,
+    })
+}
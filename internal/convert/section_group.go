@@ -0,0 +1,79 @@
+package convert
+
+import (
+    "context"
+    "encoding/json"
+)
+
+// groupSection is a pure navigation node with no page of its own, used by
+// --include manifests to introduce a heading that groups other sections
+// without generating content.
+type groupSection struct {
+    number        string
+    title         string
+    titleOverride string
+    depth         int
+    slug          string
+    treePath      string
+    name          string
+    params        map[string]any
+    weight        int
+    draft         bool
+    children      []Section
+}
+
+// NewGroupSection constructs a content-less grouping node.
+func NewGroupSection(number, title string, depth int) *groupSection {
+    return &groupSection{number: number, title: title, depth: depth}
+}
+
+func (s *groupSection) Number() string { return s.number }
+
+// Title returns titleOverride, set by a _section override file, if present.
+func (s *groupSection) Title() string {
+    if s.titleOverride != "" {
+        return s.titleOverride
+    }
+    return s.title
+}
+func (s *groupSection) Start() int             { return 0 }
+func (s *groupSection) End() int               { return 0 }
+func (s *groupSection) Depth() int             { return s.depth }
+func (s *groupSection) Slug() string           { return s.slug }
+func (s *groupSection) Children() []Section    { return s.children }
+func (s *groupSection) Name() string           { return s.name }
+func (s *groupSection) Params() map[string]any { return s.params }
+func (s *groupSection) Weight() int            { return s.weight }
+func (s *groupSection) Draft() bool            { return s.draft }
+
+// TreePath defaults to the dotted Number split into path segments; spliced
+// --include entries use an auto-generated "include-N" Number, which is
+// already unique, so there's no appendix-style override to make.
+func (s *groupSection) TreePath() string {
+    if s.treePath != "" {
+        return s.treePath
+    }
+    return numberPath(s.number)
+}
+
+func (s *groupSection) AppendChild(child Section)       { s.children = append(s.children, child) }
+func (s *groupSection) SetChildren(children []Section)  { s.children = children }
+func (s *groupSection) SetTreePath(path string)         { s.treePath = path }
+func (s *groupSection) SetSlug(slug string)             { s.slug = slug }
+func (s *groupSection) SetDepth(depth int)              { s.depth = depth }
+func (s *groupSection) SetName(name string)             { s.name = name }
+func (s *groupSection) SetTitleOverride(title string)   { s.titleOverride = title }
+func (s *groupSection) SetParams(params map[string]any) { s.params = params }
+func (s *groupSection) SetWeight(weight int)            { s.weight = weight }
+func (s *groupSection) SetDraft(draft bool)             { s.draft = draft }
+
+// Render is a no-op: groupSection has no page of its own, only children.
+func (s *groupSection) Render(ctx context.Context, outDir string, renderer Renderer) error {
+    return nil
+}
+
+func (s *groupSection) MarshalJSON() ([]byte, error) {
+    return json.Marshal(sectionJSON{
+        Number: s.number, Title: s.title, Depth: s.depth, Slug: s.slug, Children: s.children,
+    })
+}
@@ -0,0 +1,39 @@
+package convert
+
+import "testing"
+
+func TestPDFSectionTitleOverride(t *testing.T) {
+    s := NewPDFSection("1", "Intro", 1, 3, 1, "intro")
+    if got := s.Title(); got != "Intro" {
+        t.Fatalf("Title() = %q, want %q", got, "Intro")
+    }
+    s.SetTitleOverride("Introduction")
+    if got := s.Title(); got != "Introduction" {
+        t.Fatalf("Title() after override = %q, want %q", got, "Introduction")
+    }
+}
+
+func TestPDFSectionTreePathDefaultsToNumber(t *testing.T) {
+    s := NewPDFSection("1.2.3", "Deep", 1, 1, 3, "deep")
+    if got := s.TreePath(); got != "1/2/3" {
+        t.Fatalf("TreePath() = %q, want %q", got, "1/2/3")
+    }
+    s.SetTreePath("appendix/A/1")
+    if got := s.TreePath(); got != "appendix/A/1" {
+        t.Fatalf("TreePath() after SetTreePath = %q, want %q", got, "appendix/A/1")
+    }
+}
+
+func TestGroupSectionHasNoSlugByDefault(t *testing.T) {
+    g := NewGroupSection("g1", "Group", 1)
+    if got := g.Slug(); got != "" {
+        t.Fatalf("Slug() = %q, want empty for a content-less group section", got)
+    }
+}
+
+func TestGroupSectionTreePathFallsBackToNumber(t *testing.T) {
+    g := NewGroupSection("2.1", "Group", 1)
+    if got := g.TreePath(); got != numberPath("2.1") {
+        t.Fatalf("TreePath() = %q, want %q", got, numberPath("2.1"))
+    }
+}
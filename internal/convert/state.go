@@ -0,0 +1,180 @@
+package convert
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// sectionState is the persisted fingerprint of one section's last write: its
+// extracted text, the images it references, the enhancer configuration that
+// produced any AI-derived output, its ToC numbering/title, any _section
+// override file, and (for the AI-exclusive path) the extraction config.
+// Run compares this against a freshly computed fingerprint to decide
+// whether re-rendering is necessary.
+type sectionState struct {
+    TextHash     string `json:"text_hash"`
+    ImageHash    string `json:"image_hash"`
+    EnhancerHash string `json:"enhancer_hash"`
+    TOCHash      string `json:"toc_hash"`
+    OverrideHash string `json:"override_hash"`
+    ConfigHash   string `json:"config_hash"`
+}
+
+// contentMap is the content map persisted at <out>/.pdf2docs/state.json.
+type contentMap struct {
+    Sections map[string]sectionState `json:"sections"`
+}
+
+func statePath(outDir string) string {
+    return filepath.Join(outDir, ".pdf2docs", "state.json")
+}
+
+func loadContentMap(outDir string) contentMap {
+    cm := contentMap{Sections: map[string]sectionState{}}
+    b, err := os.ReadFile(statePath(outDir))
+    if err != nil {
+        return cm
+    }
+    _ = json.Unmarshal(b, &cm)
+    if cm.Sections == nil {
+        cm.Sections = map[string]sectionState{}
+    }
+    return cm
+}
+
+func saveContentMap(outDir string, cm contentMap) error {
+    dir := filepath.Join(outDir, ".pdf2docs")
+    if err := os.MkdirAll(dir, 0o755); err != nil {
+        return err
+    }
+    b, err := json.MarshalIndent(cm, "", "  ")
+    if err != nil {
+        return err
+    }
+    return os.WriteFile(statePath(outDir), b, 0o644)
+}
+
+func hashString(s string) string {
+    sum := sha256.Sum256([]byte(s))
+    return hex.EncodeToString(sum[:])
+}
+
+// hashPageTexts fingerprints the extracted text feeding one section.
+func hashPageTexts(pageTexts []PageText) string {
+    var b strings.Builder
+    for _, pt := range pageTexts {
+        b.WriteString(pt.Text)
+        b.WriteString("\x00")
+    }
+    return hashString(b.String())
+}
+
+// hashImages fingerprints the set of images a section references, by name
+// and content, so a swapped image invalidates the cached render.
+func hashImages(outDir string, imgs []ImageRef) string {
+    h := sha256.New()
+    for _, img := range imgs {
+        h.Write([]byte(img.Name))
+        if b, err := os.ReadFile(filepath.Join(outDir, "images", img.Name)); err == nil {
+            h.Write(b)
+        }
+    }
+    return hex.EncodeToString(h.Sum(nil))
+}
+
+// enhancerHash fingerprints the enhancer configuration used for AI-derived
+// output (alt text, component suggestions), so switching providers or
+// models, or turning AI on/off, invalidates the cached render.
+func enhancerHash(enhancer sectionEnhancer) string {
+    if enhancer == nil {
+        return "noop"
+    }
+    return fmt.Sprintf("%T:%s", enhancer, enhancer.ModelID())
+}
+
+// tocHash fingerprints a section's own numbering/title/hierarchy path, so a
+// ToC repair that renumbers or retitles a section without touching its page
+// text still invalidates the cached render.
+func tocHash(s Section) string {
+    return hashString(s.Number() + "\x00" + s.Title() + "\x00" + s.TreePath())
+}
+
+// overrideHash fingerprints the raw bytes of outDir/<slug>/_section.* (in
+// whichever of overrideExts is present), so hand-editing an override file
+// invalidates the cached render even though the section's own PDF content
+// hasn't changed. A missing override file hashes to "" across runs.
+func overrideHash(outDir, slug string) string {
+    base := filepath.Join(outDir, slug, "_section")
+    for _, c := range overrideExts {
+        if b, err := os.ReadFile(base + c.ext); err == nil {
+            return hashString(string(b))
+        }
+    }
+    return ""
+}
+
+// hashFile fingerprints a file's raw bytes, e.g. the source PDF, so Run can
+// detect that the input changed even where it isn't otherwise reflected (a
+// ToC-only edit, a re-exported PDF with identical extracted text). A
+// missing or unreadable file hashes to "".
+func hashFile(path string) string {
+    b, err := os.ReadFile(path)
+    if err != nil {
+        return ""
+    }
+    return hashString(string(b))
+}
+
+// sectionChanged reports whether s's content differs from what was last
+// recorded for its slug in cm, and returns the freshly computed state to
+// persist either way. configHash carries whatever is constant across every
+// section in one Run call but still needs to invalidate the whole content
+// map when it changes: the source PDF's hash, plus (for the AI-exclusive
+// path) MaxDepth/ToCPages/ModelID, since that path has no per-section ToC
+// line of its own to fingerprint.
+func sectionChanged(cm contentMap, outDir string, s Section, pageTexts []PageText, imgs []ImageRef, enhancer sectionEnhancer, configHash string) (sectionState, bool) {
+    fresh := sectionState{
+        TextHash:     hashPageTexts(pageTexts),
+        ImageHash:    hashImages(outDir, imgs),
+        EnhancerHash: enhancerHash(enhancer),
+        TOCHash:      tocHash(s),
+        OverrideHash: overrideHash(outDir, s.Slug()),
+        ConfigHash:   configHash,
+    }
+    prev, ok := cm.Sections[s.Slug()]
+    return fresh, !ok || prev != fresh
+}
+
+// diffReasons names which parts of fresh's fingerprint differ from prev, for
+// --why's diagnostic output. hadPrev distinguishes "never built before" from
+// "built before, nothing changed".
+func diffReasons(prev, fresh sectionState, hadPrev bool) []string {
+    if !hadPrev {
+        return []string{"no prior record (new section)"}
+    }
+    var reasons []string
+    if prev.TextHash != fresh.TextHash {
+        reasons = append(reasons, "page text")
+    }
+    if prev.ImageHash != fresh.ImageHash {
+        reasons = append(reasons, "images")
+    }
+    if prev.EnhancerHash != fresh.EnhancerHash {
+        reasons = append(reasons, "enhancer/model")
+    }
+    if prev.TOCHash != fresh.TOCHash {
+        reasons = append(reasons, "toc numbering/title")
+    }
+    if prev.OverrideHash != fresh.OverrideHash {
+        reasons = append(reasons, "_section override file")
+    }
+    if prev.ConfigHash != fresh.ConfigHash {
+        reasons = append(reasons, "source pdf / extraction config")
+    }
+    return reasons
+}
@@ -0,0 +1,84 @@
+package convert
+
+import "testing"
+
+func TestSectionChangedNewSection(t *testing.T) {
+    s := NewPDFSection("1", "Intro", 1, 2, 1, "intro")
+    cm := loadContentMap(t.TempDir())
+    _, changed := sectionChanged(cm, t.TempDir(), s, nil, nil, nil, "cfg")
+    if !changed {
+        t.Fatal("sectionChanged() = false for a section with no prior record, want true")
+    }
+}
+
+func TestSectionChangedUnchangedWhenIdentical(t *testing.T) {
+    outDir := t.TempDir()
+    s := NewPDFSection("1", "Intro", 1, 2, 1, "intro")
+    pageTexts := []PageText{{Page: 1, Text: "hello"}}
+
+    fresh, changed := sectionChanged(contentMap{Sections: map[string]sectionState{}}, outDir, s, pageTexts, nil, nil, "cfg")
+    if !changed {
+        t.Fatal("sectionChanged() = false on first computation, want true (no prior record)")
+    }
+
+    cm := contentMap{Sections: map[string]sectionState{"intro": fresh}}
+    _, changed = sectionChanged(cm, outDir, s, pageTexts, nil, nil, "cfg")
+    if changed {
+        t.Fatal("sectionChanged() = true with identical inputs, want false")
+    }
+}
+
+func TestSectionChangedWhenTextDiffers(t *testing.T) {
+    outDir := t.TempDir()
+    s := NewPDFSection("1", "Intro", 1, 2, 1, "intro")
+    fresh, _ := sectionChanged(contentMap{Sections: map[string]sectionState{}}, outDir, s, []PageText{{Page: 1, Text: "hello"}}, nil, nil, "cfg")
+    cm := contentMap{Sections: map[string]sectionState{"intro": fresh}}
+
+    _, changed := sectionChanged(cm, outDir, s, []PageText{{Page: 1, Text: "goodbye"}}, nil, nil, "cfg")
+    if !changed {
+        t.Fatal("sectionChanged() = false after page text changed, want true")
+    }
+}
+
+func TestSectionChangedWhenConfigHashDiffers(t *testing.T) {
+    outDir := t.TempDir()
+    s := NewPDFSection("1", "Intro", 1, 2, 1, "intro")
+    pageTexts := []PageText{{Page: 1, Text: "hello"}}
+    fresh, _ := sectionChanged(contentMap{Sections: map[string]sectionState{}}, outDir, s, pageTexts, nil, nil, "cfg-a")
+    cm := contentMap{Sections: map[string]sectionState{"intro": fresh}}
+
+    _, changed := sectionChanged(cm, outDir, s, pageTexts, nil, nil, "cfg-b")
+    if !changed {
+        t.Fatal("sectionChanged() = false after configHash changed, want true")
+    }
+}
+
+func TestDiffReasonsNoPriorRecord(t *testing.T) {
+    reasons := diffReasons(sectionState{}, sectionState{}, false)
+    if len(reasons) != 1 || reasons[0] != "no prior record (new section)" {
+        t.Fatalf("diffReasons() = %v, want a single new-section reason", reasons)
+    }
+}
+
+func TestDiffReasonsNamesEachChangedField(t *testing.T) {
+    prev := sectionState{TextHash: "a", ImageHash: "a", EnhancerHash: "a", TOCHash: "a", OverrideHash: "a", ConfigHash: "a"}
+    fresh := sectionState{TextHash: "b", ImageHash: "a", EnhancerHash: "a", TOCHash: "a", OverrideHash: "a", ConfigHash: "b"}
+
+    reasons := diffReasons(prev, fresh, true)
+    if len(reasons) != 2 {
+        t.Fatalf("diffReasons() = %v, want exactly 2 reasons", reasons)
+    }
+}
+
+func TestDiffReasonsUnchangedReturnsEmpty(t *testing.T) {
+    prev := sectionState{TextHash: "a"}
+    if reasons := diffReasons(prev, prev, true); len(reasons) != 0 {
+        t.Fatalf("diffReasons() = %v, want none for identical states", reasons)
+    }
+}
+
+func TestHashFileMissingReturnsEmpty(t *testing.T) {
+    if got := hashFile("/does/not/exist"); got != "" {
+        t.Fatalf("hashFile() = %q, want empty for a missing file", got)
+    }
+}
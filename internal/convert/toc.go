@@ -1,32 +1,215 @@
 package convert
 
 import (
+    "fmt"
     "regexp"
     "sort"
     "strconv"
     "strings"
+
+    "github.com/thywilljoshua/pdf-to-docs/internal/sectiontree"
 )
 
-// Patterns for ToC entries: numeric, roman numerals, alphabetic appendices, and explicit Appendix prefix.
+// TOCScheme recognizes one ToC-line numbering convention (numeric, roman,
+// "Chapter N", CJK, ...), turning a single normalized line into its raw
+// number/title/page/depth fields. Schemes are tried in order by matchToC;
+// the first to match wins.
+type TOCScheme interface {
+    // Name identifies the scheme, used by Config.TOCSchemes to select and
+    // reorder active schemes and by --dump-toc to report which one matched.
+    Name() string
+    // Match attempts to parse line as one ToC entry in this scheme's
+    // numbering convention.
+    Match(line string) (number, title string, page, depth int, ok bool)
+}
+
+// Patterns backing the built-in schemes.
+var (
+    tocNumRe          = regexp.MustCompile(`^\s*(\d+(?:\.\d+)*)\s+(.+?)\s+(\d+)\s*$`)
+    tocRomanRe        = regexp.MustCompile(`^\s*([IVXLCDM]+)(?:\.([0-9]+))?\s+(.+?)\s+(\d+)\s*$`)
+    tocAlphaRe        = regexp.MustCompile(`^\s*([A-Z](?:\.[0-9]+)*)\s+(.+?)\s+(\d+)\s*$`)
+    tocAppendixRe     = regexp.MustCompile(`^\s*(?:Appendix|APPENDIX)\s+([A-Z](?:\.[0-9]+)*)\s+(.+?)\s+(\d+)\s*$`)
+    tocChapterWordRe  = regexp.MustCompile(`(?i)^\s*(?:Chapter|Part|Section)\s+(\d+(?:\.\d+)*)\s*[-—:]?\s*(.+?)\s+(\d+)\s*$`)
+    tocBracketRe      = regexp.MustCompile(`^\s*\[(\d+(?:\.\d+)*)\]\s+(.+?)\s+(\d+)\s*$`)
+    tocCJKRe          = regexp.MustCompile(`^\s*第([0-9一二三四五六七八九十]+)[章节]\s*(.+?)\s+(\d+)\s*$`)
+)
+
+type appendixScheme struct{}
+
+func (appendixScheme) Name() string { return "appendix" }
+func (appendixScheme) Match(line string) (string, string, int, int, bool) {
+    m := tocAppendixRe.FindStringSubmatch(line)
+    if len(m) != 4 {
+        return "", "", 0, 0, false
+    }
+    p, _ := strconv.Atoi(m[3])
+    return m[1], strings.TrimSpace(m[2]), p, strings.Count(m[1], ".") + 1, true
+}
+
+type numericScheme struct{}
+
+func (numericScheme) Name() string { return "numeric" }
+func (numericScheme) Match(line string) (string, string, int, int, bool) {
+    m := tocNumRe.FindStringSubmatch(line)
+    if len(m) != 4 {
+        return "", "", 0, 0, false
+    }
+    p, _ := strconv.Atoi(m[3])
+    return m[1], strings.TrimSpace(m[2]), p, strings.Count(m[1], ".") + 1, true
+}
+
+type alphaScheme struct{}
+
+func (alphaScheme) Name() string { return "alpha" }
+func (alphaScheme) Match(line string) (string, string, int, int, bool) {
+    m := tocAlphaRe.FindStringSubmatch(line)
+    if len(m) != 4 {
+        return "", "", 0, 0, false
+    }
+    p, _ := strconv.Atoi(m[3])
+    return m[1], strings.TrimSpace(m[2]), p, strings.Count(m[1], ".") + 1, true
+}
+
+type romanScheme struct{}
+
+func (romanScheme) Name() string { return "roman" }
+func (romanScheme) Match(line string) (string, string, int, int, bool) {
+    m := tocRomanRe.FindStringSubmatch(line)
+    if len(m) != 5 {
+        return "", "", 0, 0, false
+    }
+    p, _ := strconv.Atoi(m[4])
+    // depth is 1 if only roman; if has .<n>, treat as depth 2
+    num, depth := m[1], 1
+    if m[2] != "" {
+        num = num + "." + m[2]
+        depth = 2
+    }
+    return num, strings.TrimSpace(m[3]), p, depth, true
+}
+
+// chapterWordScheme matches prose-style headings like "Chapter 3 — Foo 42"
+// or "Part 2: Bar 17" that a numeric/alpha/roman regex misses outright.
+type chapterWordScheme struct{}
+
+func (chapterWordScheme) Name() string { return "chapter-word" }
+func (chapterWordScheme) Match(line string) (string, string, int, int, bool) {
+    m := tocChapterWordRe.FindStringSubmatch(line)
+    if len(m) != 4 {
+        return "", "", 0, 0, false
+    }
+    p, _ := strconv.Atoi(m[3])
+    return m[1], strings.TrimSpace(m[2]), p, strings.Count(m[1], ".") + 1, true
+}
+
+// bracketScheme matches bracketed numbering like "[3.2] Foo 42".
+type bracketScheme struct{}
+
+func (bracketScheme) Name() string { return "bracket" }
+func (bracketScheme) Match(line string) (string, string, int, int, bool) {
+    m := tocBracketRe.FindStringSubmatch(line)
+    if len(m) != 4 {
+        return "", "", 0, 0, false
+    }
+    p, _ := strconv.Atoi(m[3])
+    return m[1], strings.TrimSpace(m[2]), p, strings.Count(m[1], ".") + 1, true
+}
+
+// cjkScheme matches Chinese chapter headings like "第一章 绪论 12" or
+// "第3节 背景 5". The numeral token (Arabic or CJK) is kept as Number
+// verbatim rather than converted to an int, matching how roman numerals are
+// kept as display tokens elsewhere in this file.
+type cjkScheme struct{}
+
+func (cjkScheme) Name() string { return "cjk" }
+func (cjkScheme) Match(line string) (string, string, int, int, bool) {
+    m := tocCJKRe.FindStringSubmatch(line)
+    if len(m) != 4 {
+        return "", "", 0, 0, false
+    }
+    p, _ := strconv.Atoi(m[3])
+    return m[1], strings.TrimSpace(m[2]), p, 1, true
+}
+
 var (
-    tocNumRe      = regexp.MustCompile(`^\s*(\d+(?:\.\d+)*)\s+(.+?)\s+(\d+)\s*$`)
-    tocRomanRe    = regexp.MustCompile(`^\s*([IVXLCDM]+)(?:\.([0-9]+))?\s+(.+?)\s+(\d+)\s*$`)
-    tocAlphaRe    = regexp.MustCompile(`^\s*([A-Z](?:\.[0-9]+)*)\s+(.+?)\s+(\d+)\s*$`)
-    tocAppendixRe = regexp.MustCompile(`^\s*(?:Appendix|APPENDIX)\s+([A-Z](?:\.[0-9]+)*)\s+(.+?)\s+(\d+)\s*$`)
+    schemeRegistry = map[string]TOCScheme{}
+    schemeOrder    []string
 )
 
+// RegisterScheme adds (or replaces) a TOCScheme in the global registry that
+// Config.TOCSchemes and --dump-toc draw from. A later RegisterScheme call
+// for an already-registered Name overwrites it in place, keeping its
+// original position in schemeOrder.
+func RegisterScheme(s TOCScheme) {
+    name := s.Name()
+    if _, exists := schemeRegistry[name]; !exists {
+        schemeOrder = append(schemeOrder, name)
+    }
+    schemeRegistry[name] = s
+}
+
+func init() {
+    RegisterScheme(appendixScheme{})
+    RegisterScheme(numericScheme{})
+    RegisterScheme(alphaScheme{})
+    RegisterScheme(romanScheme{})
+    RegisterScheme(chapterWordScheme{})
+    RegisterScheme(bracketScheme{})
+    RegisterScheme(cjkScheme{})
+}
+
+// resolveSchemes resolves names (Config.TOCSchemes) against the registry in
+// the order given; an unknown name is skipped. An empty names falls back to
+// every registered scheme in registration order (the behavior before
+// Config.TOCSchemes existed).
+func resolveSchemes(names []string) []TOCScheme {
+    if len(names) == 0 {
+        names = schemeOrder
+    }
+    out := make([]TOCScheme, 0, len(names))
+    for _, n := range names {
+        if s, ok := schemeRegistry[n]; ok {
+            out = append(out, s)
+        }
+    }
+    return out
+}
+
+// prioritizeScheme moves the scheme named hint to the front of schemes, so
+// an Enhancer.RepairToC hint about which format it normalized lines to
+// keeps matchToC's choice - and therefore depth assignment - consistent
+// with what was actually produced. A hint naming an inactive or unknown
+// scheme is a no-op.
+func prioritizeScheme(schemes []TOCScheme, hint string) []TOCScheme {
+    if hint == "" {
+        return schemes
+    }
+    for i, s := range schemes {
+        if s.Name() == hint {
+            out := make([]TOCScheme, 0, len(schemes))
+            out = append(out, s)
+            out = append(out, schemes[:i]...)
+            out = append(out, schemes[i+1:]...)
+            return out
+        }
+    }
+    return schemes
+}
+
 type tocEntry struct {
-    Number string // display number token (e.g., 1.2, I, A.1)
-    Title  string
-    Page   int
-    Depth  int
+    Number   string // display number token (e.g., 1.2, I, A.1, 一)
+    Title    string
+    Page     int
+    Depth    int
+    Appendix bool   // matched the explicit "Appendix X" heading, not a bare alpha entry
+    Scheme   string // name of the TOCScheme that matched, for --dump-toc
 }
 
-func parseToCLines(lines []string) []tocEntry {
+func parseToCLines(lines []string, schemes []TOCScheme) []tocEntry {
     var out []tocEntry
     for _, line := range lines {
         line = normalizeDotLeaders(line)
-        if e, ok := matchToC(line); ok {
+        if e, ok := matchToC(line, schemes); ok {
             out = append(out, e)
         }
     }
@@ -34,46 +217,38 @@ func parseToCLines(lines []string) []tocEntry {
     return out
 }
 
-func matchToC(line string) (tocEntry, bool) {
-    if m := tocAppendixRe.FindStringSubmatch(line); len(m) == 4 {
-        p, _ := strconv.Atoi(m[3])
-        key := m[1]
-        depth := strings.Count(key, ".") + 1
-        return tocEntry{Number: key, Title: strings.TrimSpace(m[2]), Page: p, Depth: depth}, true
-    }
-    if m := tocNumRe.FindStringSubmatch(line); len(m) == 4 {
-        p, _ := strconv.Atoi(m[3])
-        depth := strings.Count(m[1], ".") + 1
-        return tocEntry{Number: m[1], Title: strings.TrimSpace(m[2]), Page: p, Depth: depth}, true
-    }
-    if m := tocAlphaRe.FindStringSubmatch(line); len(m) == 4 {
-        p, _ := strconv.Atoi(m[3])
-        depth := strings.Count(m[1], ".") + 1
-        return tocEntry{Number: m[1], Title: strings.TrimSpace(m[2]), Page: p, Depth: depth}, true
-    }
-    if m := tocRomanRe.FindStringSubmatch(line); len(m) == 5 {
-        p, _ := strconv.Atoi(m[4])
-        // depth is 1 if only roman; if has .<n>, treat as depth 2
-        depth := 1
-        num := m[1]
-        if m[2] != "" {
-            num = num + "." + m[2]
-            depth = 2
+func matchToC(line string, schemes []TOCScheme) (tocEntry, bool) {
+    for _, sc := range schemes {
+        if number, title, page, depth, ok := sc.Match(line); ok {
+            return tocEntry{Number: number, Title: title, Page: page, Depth: depth, Appendix: sc.Name() == "appendix", Scheme: sc.Name()}, true
         }
-        return tocEntry{Number: num, Title: strings.TrimSpace(m[3]), Page: p, Depth: depth}, true
     }
     return tocEntry{}, false
 }
 
+// dumpTOC prints, for each raw ToC line, which scheme (if any) matched it -
+// --dump-toc's debugging aid for tuning Config.TOCSchemes against a
+// particular PDF's numbering convention.
+func dumpTOC(lines []string, schemes []TOCScheme) {
+    for _, line := range lines {
+        norm := normalizeDotLeaders(line)
+        if e, ok := matchToC(norm, schemes); ok {
+            fmt.Printf("toc: %q -> %s (number=%s depth=%d page=%d)\n", line, e.Scheme, e.Number, e.Depth, e.Page)
+        } else {
+            fmt.Printf("toc: %q -> no match\n", line)
+        }
+    }
+}
+
 func isToCLine(s string) bool {
-    s = normalizeDotLeaders(s)
-    return tocAppendixRe.MatchString(s) || tocNumRe.MatchString(s) || tocAlphaRe.MatchString(s) || tocRomanRe.MatchString(s)
+    _, ok := matchToC(normalizeDotLeaders(s), resolveSchemes(nil))
+    return ok
 }
 
 func normalizeDotLeaders(s string) string {
-    s = strings.ReplaceAll(s, "\u2022", " ")
-    s = strings.ReplaceAll(s, "\u00B7", " ")
-    s = strings.ReplaceAll(s, "\u2026", " ... ")
+    s = strings.ReplaceAll(s, "•", " ")
+    s = strings.ReplaceAll(s, "·", " ")
+    s = strings.ReplaceAll(s, "…", " ... ")
     s = strings.ReplaceAll(s, "·", " ")
     s = strings.ReplaceAll(s, "…", " ... ")
     s = strings.ReplaceAll(s, "..........................................................................................................", " ")
@@ -82,16 +257,18 @@ func normalizeDotLeaders(s string) string {
     return s
 }
 
+// buildSections turns ToC entries into a flat, page-ordered []Section,
+// inserting each into a sectiontree keyed by entryPath so a numbering gap
+// (1 -> 1.1.1) or mixed numeric/roman/alpha/appendix schemes still produce
+// a single connected tree. maxDepth is enforced as one Prune pass over the
+// tree instead of a per-entry filter.
 func buildSections(entries []tocEntry, maxDepth int) []Section {
     if maxDepth <= 0 {
         maxDepth = 10
     }
     n := len(entries)
-    var sections []Section
+    tree := sectiontree.New[Section]()
     for i, e := range entries {
-        if e.Depth > maxDepth {
-            continue
-        }
         end := e.Page
         if i < n-1 {
             end = entries[i+1].Page - 1
@@ -100,7 +277,37 @@ func buildSections(entries []tocEntry, maxDepth int) []Section {
             }
         }
         slug := slugify(e.Number + "-" + e.Title)
-        sections = append(sections, Section{Number: e.Number, Title: e.Title, Start: e.Page, End: end, Depth: e.Depth, Slug: slug})
+        sec := NewPDFSection(e.Number, e.Title, e.Page, end, e.Depth, slug)
+        if e.Appendix {
+            // Diverge from the default Number-derived TreePath so later
+            // hierarchy building keeps this appendix separate from an
+            // unrelated top-level section sharing the same letter.
+            sec.SetTreePath(entryPath(e))
+        }
+        tree.Insert(sec.TreePath(), sec)
     }
+    tree.Prune(func(node *sectiontree.Node[Section]) bool {
+        return node.HasValue() && node.Value().Depth() > maxDepth
+    })
+
+    var sections []Section
+    tree.Walk(func(_ string, s Section) bool {
+        sections = append(sections, s)
+        return true
+    })
     return sections
 }
+
+// entryPath maps a ToC entry's display Number to the sectiontree path it
+// should be inserted at: dotted segments become slash-joined path
+// segments, with an "appendix" root segment for entries matched by an
+// explicit "Appendix X" heading so they never collide with an unrelated
+// alpha-lettered top-level section sharing the same letter (e.g. parts
+// numbered "A", "B" alongside an "Appendix A").
+func entryPath(e tocEntry) string {
+    segs := strings.Split(e.Number, ".")
+    if e.Appendix {
+        segs = append([]string{"appendix"}, segs...)
+    }
+    return strings.Join(segs, "/")
+}
@@ -0,0 +1,84 @@
+package convert
+
+import "testing"
+
+func TestMatchToCNumericScheme(t *testing.T) {
+    e, ok := matchToC("1.2 Getting Started 14", resolveSchemes(nil))
+    if !ok {
+        t.Fatal("matchToC() = false, want a numeric match")
+    }
+    if e.Scheme != "numeric" || e.Number != "1.2" || e.Title != "Getting Started" || e.Page != 14 || e.Depth != 2 {
+        t.Fatalf("matchToC() = %+v, want numeric 1.2 Getting Started page 14 depth 2", e)
+    }
+}
+
+func TestMatchToCChapterWordScheme(t *testing.T) {
+    e, ok := matchToC("Chapter 3 - Setup 42", resolveSchemes(nil))
+    if !ok {
+        t.Fatal("matchToC() = false, want a chapter-word match")
+    }
+    if e.Scheme != "chapter-word" || e.Number != "3" || e.Page != 42 {
+        t.Fatalf("matchToC() = %+v, want chapter-word 3 page 42", e)
+    }
+}
+
+func TestMatchToCBracketScheme(t *testing.T) {
+    e, ok := matchToC("[3.2] Foo 42", resolveSchemes(nil))
+    if !ok {
+        t.Fatal("matchToC() = false, want a bracket match")
+    }
+    if e.Scheme != "bracket" || e.Number != "3.2" || e.Page != 42 {
+        t.Fatalf("matchToC() = %+v, want bracket 3.2 page 42", e)
+    }
+}
+
+func TestMatchToCCJKScheme(t *testing.T) {
+    e, ok := matchToC("第一章 绪论 12", resolveSchemes(nil))
+    if !ok {
+        t.Fatal("matchToC() = false, want a cjk match")
+    }
+    if e.Scheme != "cjk" || e.Page != 12 {
+        t.Fatalf("matchToC() = %+v, want cjk page 12", e)
+    }
+}
+
+func TestResolveSchemesFiltersUnknownNames(t *testing.T) {
+    schemes := resolveSchemes([]string{"numeric", "made-up", "bracket"})
+    if len(schemes) != 2 || schemes[0].Name() != "numeric" || schemes[1].Name() != "bracket" {
+        t.Fatalf("resolveSchemes() = %v, want [numeric bracket] with the unknown name dropped", schemeNames(schemes))
+    }
+}
+
+func TestResolveSchemesEmptyReturnsAllRegistered(t *testing.T) {
+    schemes := resolveSchemes(nil)
+    if len(schemes) != len(schemeOrder) {
+        t.Fatalf("resolveSchemes(nil) returned %d schemes, want all %d registered", len(schemes), len(schemeOrder))
+    }
+}
+
+func TestPrioritizeSchemeMovesHintToFront(t *testing.T) {
+    schemes := resolveSchemes(nil)
+    reordered := prioritizeScheme(schemes, "bracket")
+    if reordered[0].Name() != "bracket" {
+        t.Fatalf("prioritizeScheme() put %q first, want %q", reordered[0].Name(), "bracket")
+    }
+    if len(reordered) != len(schemes) {
+        t.Fatalf("prioritizeScheme() changed scheme count: got %d, want %d", len(reordered), len(schemes))
+    }
+}
+
+func TestPrioritizeSchemeUnknownHintIsNoop(t *testing.T) {
+    schemes := resolveSchemes(nil)
+    reordered := prioritizeScheme(schemes, "not-a-scheme")
+    if reordered[0].Name() != schemes[0].Name() {
+        t.Fatalf("prioritizeScheme() with an unknown hint reordered schemes, want unchanged order")
+    }
+}
+
+func schemeNames(schemes []TOCScheme) []string {
+    names := make([]string, len(schemes))
+    for i, s := range schemes {
+        names[i] = s.Name()
+    }
+    return names
+}
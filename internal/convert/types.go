@@ -2,18 +2,9 @@ package convert
 
 import (
     "github.com/thywilljoshua/pdf-to-docs/internal/ai"
+    "github.com/thywilljoshua/pdf-to-docs/internal/cache"
 )
 
-type Section struct {
-    Number   string    `json:"number"`
-    Title    string    `json:"title"`
-    Start    int       `json:"start_page"`
-    End      int       `json:"end_page"`
-    Depth    int       `json:"depth"`
-    Slug     string    `json:"slug"`
-    Children []Section `json:"children,omitempty"`
-}
-
 type Result struct {
     Sections []Section `json:"sections"`
     Images   int       `json:"images_extracted"`
@@ -29,8 +20,39 @@ type Config struct {
     ToCPages      int
     SiteName      string
     SlugPrefix    string
+    // Format selects the Renderer: mintlify (default), docusaurus, starlight, or mkdocs.
+    Format        string
     AIExclusive   bool
+    // Force bypasses the persisted content map and re-renders every section.
+    Force         bool
+    // Include, if set, points at a YAML or JSON manifest of extra sections
+    // (existing files or bare navigation groups) to splice into the
+    // PDF-derived tree before/after/as a child of a given section number.
+    Include       string
+    // Concurrency caps how many sections Run renders in parallel (<= 0
+    // defaults to runtime.NumCPU()).
+    Concurrency   int
+    // AIConcurrency caps how many Enhancer calls may be in flight at once,
+    // independent of Concurrency, to respect provider rate limits
+    // (<= 0 defaults to 4).
+    AIConcurrency int
     Enhancer      ai.Enhancer
+    // Cache, if set, backs Enhancer with the cross-cutting AI response
+    // cache; Run reports its hit/miss/eviction counters on completion.
+    Cache         *cache.Cache
+    // Why, if set to a section slug, makes Run print which part of that
+    // section's fingerprint (page text, images, enhancer/model, ToC
+    // numbering, _section override, or source PDF/extraction config)
+    // changed since the last run, alongside its normal output.
+    Why string
+    // TOCSchemes selects and orders which registered TOCScheme names
+    // matchToC tries against each ToC line. Empty uses every registered
+    // scheme in registration order (appendix, numeric, alpha, roman,
+    // chapter-word, bracket, cjk).
+    TOCSchemes []string
+    // DumpToC, if set, makes Run print which scheme (if any) matched each
+    // detected ToC line before parsing them into sections.
+    DumpToC bool
 }
 
 // Alias types from ai package for convenience
@@ -0,0 +1,224 @@
+// Package frontmatter splits a generated page into its front matter and
+// body and serializes the two back together, mirroring the shape Hugo's
+// page parser returns. It backs both the Renderer front matter writers and
+// the convert-frontmatter round-trip subcommand, so generation and later
+// hand edits go through the same code path instead of drifting apart.
+package frontmatter
+
+import (
+    "bufio"
+    "bytes"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+    "time"
+
+    "github.com/BurntSushi/toml"
+    "gopkg.in/yaml.v3"
+)
+
+// Format names the front-matter delimiter style a page uses.
+type Format string
+
+const (
+    YAML Format = "yaml"
+    TOML Format = "toml"
+    JSON Format = "json"
+    None Format = ""
+)
+
+// ParsedPage is the result of splitting a page into its front matter and
+// body.
+type ParsedPage struct {
+    Format      Format
+    FrontMatter map[string]any
+    Content     []byte
+}
+
+var (
+    yamlFence = []byte("---")
+    tomlFence = []byte("+++")
+)
+
+// ParseFrontMatterAndContent splits r into front matter and body, detecting
+// YAML ("---"), TOML ("+++"), and JSON ("{ ... }") fences. A page with none
+// of these markers is returned with Format None and its entire contents as
+// Content.
+func ParseFrontMatterAndContent(r io.Reader) (*ParsedPage, error) {
+    b, err := io.ReadAll(r)
+    if err != nil {
+        return nil, err
+    }
+
+    switch {
+    case bytes.HasPrefix(b, yamlFence):
+        return splitFenced(b, YAML, yamlFence)
+    case bytes.HasPrefix(b, tomlFence):
+        return splitFenced(b, TOML, tomlFence)
+    case bytes.HasPrefix(b, []byte("{")):
+        return splitJSON(b)
+    default:
+        return &ParsedPage{Format: None, Content: b}, nil
+    }
+}
+
+// splitFenced extracts the front matter between a pair of fence lines (e.g.
+// "---" for YAML, "+++" for TOML) and unmarshals it according to format.
+func splitFenced(b []byte, format Format, fence []byte) (*ParsedPage, error) {
+    lines := bytes.SplitAfter(b, []byte("\n"))
+
+    end := -1
+    for i := 1; i < len(lines); i++ {
+        if bytes.Equal(bytes.TrimRight(lines[i], "\n"), fence) {
+            end = i
+            break
+        }
+    }
+    if end < 0 {
+        return nil, fmt.Errorf("frontmatter: unterminated %s fence", format)
+    }
+
+    raw := bytes.Join(lines[1:end], nil)
+    fm := make(map[string]any)
+    var err error
+    switch format {
+    case YAML:
+        err = yaml.Unmarshal(raw, &fm)
+    case TOML:
+        err = toml.Unmarshal(raw, &fm)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("frontmatter: parsing %s: %w", format, err)
+    }
+
+    content := bytes.Join(lines[end+1:], nil)
+    return &ParsedPage{Format: format, FrontMatter: fm, Content: content}, nil
+}
+
+// splitJSON extracts a Hugo-style JSON front matter block: a top-level "{"
+// on its own line through the matching "}" on its own line, tracked by
+// brace depth so nested objects don't end the block early.
+func splitJSON(b []byte) (*ParsedPage, error) {
+    scanner := bufio.NewScanner(bytes.NewReader(b))
+    scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+    var raw, rest bytes.Buffer
+    depth := 0
+    inBody := false
+    for scanner.Scan() {
+        line := scanner.Text()
+        if inBody {
+            rest.WriteString(line)
+            rest.WriteByte('\n')
+            continue
+        }
+        raw.WriteString(line)
+        raw.WriteByte('\n')
+        depth += strings.Count(line, "{") - strings.Count(line, "}")
+        if depth == 0 {
+            inBody = true
+        }
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, err
+    }
+    if depth != 0 {
+        return nil, errors.New("frontmatter: unterminated JSON fence")
+    }
+
+    fm := make(map[string]any)
+    if err := json.Unmarshal(raw.Bytes(), &fm); err != nil {
+        return nil, fmt.Errorf("frontmatter: parsing json: %w", err)
+    }
+    return &ParsedPage{Format: JSON, FrontMatter: fm, Content: rest.Bytes()}, nil
+}
+
+// Serialize renders a page in format, fencing frontMatter around content.
+// Any time.Time in frontMatter (including nested in maps or slices) is
+// normalized to an RFC3339 string first, so every format agrees on one
+// timestamp representation instead of TOML's native datetime literal or
+// Go's default time.Time.String().
+func Serialize(format Format, frontMatter map[string]any, content []byte) ([]byte, error) {
+    if format == None {
+        return content, nil
+    }
+    fm, _ := normalizeTimes(frontMatter).(map[string]any)
+
+    var raw []byte
+    var err error
+    var open, close string
+    switch format {
+    case YAML:
+        raw, err = yaml.Marshal(fm)
+        open, close = "---\n", "---\n"
+    case TOML:
+        var buf bytes.Buffer
+        err = toml.NewEncoder(&buf).Encode(fm)
+        raw = buf.Bytes()
+        open, close = "+++\n", "+++\n"
+    case JSON:
+        raw, err = json.MarshalIndent(fm, "", "  ")
+        raw = append(raw, '\n')
+    default:
+        return nil, fmt.Errorf("frontmatter: unknown format %q", format)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("frontmatter: serializing %s: %w", format, err)
+    }
+
+    var out bytes.Buffer
+    out.WriteString(open)
+    out.Write(raw)
+    out.WriteString(close)
+    out.WriteByte('\n')
+    out.Write(content)
+    return out.Bytes(), nil
+}
+
+// DecodeDocument unmarshals b as a bare document in format, with no
+// "---"/"+++" fence around it. Used for sibling override files like
+// _section.yaml, which are themselves plain front-matter-shaped documents
+// rather than a fenced page's front matter block.
+func DecodeDocument(format Format, b []byte) (map[string]any, error) {
+    m := make(map[string]any)
+    var err error
+    switch format {
+    case YAML:
+        err = yaml.Unmarshal(b, &m)
+    case TOML:
+        err = toml.Unmarshal(b, &m)
+    case JSON:
+        err = json.Unmarshal(b, &m)
+    default:
+        return nil, fmt.Errorf("frontmatter: unknown format %q", format)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("frontmatter: decoding %s: %w", format, err)
+    }
+    return m, nil
+}
+
+// normalizeTimes walks v, replacing any time.Time with its RFC3339 string
+// form.
+func normalizeTimes(v any) any {
+    switch t := v.(type) {
+    case time.Time:
+        return t.Format(time.RFC3339)
+    case map[string]any:
+        out := make(map[string]any, len(t))
+        for k, val := range t {
+            out[k] = normalizeTimes(val)
+        }
+        return out
+    case []any:
+        out := make([]any, len(t))
+        for i, val := range t {
+            out[i] = normalizeTimes(val)
+        }
+        return out
+    default:
+        return v
+    }
+}
@@ -0,0 +1,82 @@
+package frontmatter
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+func TestParseFrontMatterAndContentYAML(t *testing.T) {
+    page := "---\ntitle: Intro\nweight: 2\n---\n# Hello\n"
+    p, err := ParseFrontMatterAndContent(strings.NewReader(page))
+    if err != nil {
+        t.Fatalf("ParseFrontMatterAndContent() error = %v", err)
+    }
+    if p.Format != YAML {
+        t.Fatalf("Format = %v, want YAML", p.Format)
+    }
+    if p.FrontMatter["title"] != "Intro" {
+        t.Fatalf("FrontMatter[title] = %v, want Intro", p.FrontMatter["title"])
+    }
+    if got := strings.TrimSpace(string(p.Content)); got != "# Hello" {
+        t.Fatalf("Content = %q, want %q", got, "# Hello")
+    }
+}
+
+func TestParseFrontMatterAndContentNone(t *testing.T) {
+    p, err := ParseFrontMatterAndContent(strings.NewReader("# No front matter\n"))
+    if err != nil {
+        t.Fatalf("ParseFrontMatterAndContent() error = %v", err)
+    }
+    if p.Format != None {
+        t.Fatalf("Format = %v, want None", p.Format)
+    }
+    if string(p.Content) != "# No front matter\n" {
+        t.Fatalf("Content = %q, want original bytes unchanged", p.Content)
+    }
+}
+
+func TestParseFrontMatterAndContentUnterminatedFence(t *testing.T) {
+    if _, err := ParseFrontMatterAndContent(strings.NewReader("---\ntitle: Intro\n")); err == nil {
+        t.Fatal("ParseFrontMatterAndContent() error = nil, want unterminated fence error")
+    }
+}
+
+func TestSerializeRoundTripYAML(t *testing.T) {
+    fm := map[string]any{"title": "Intro", "weight": 2}
+    out, err := Serialize(YAML, fm, []byte("# Hello\n"))
+    if err != nil {
+        t.Fatalf("Serialize() error = %v", err)
+    }
+    p, err := ParseFrontMatterAndContent(bytes.NewReader(out))
+    if err != nil {
+        t.Fatalf("ParseFrontMatterAndContent() error = %v", err)
+    }
+    if p.FrontMatter["title"] != "Intro" {
+        t.Fatalf("round-tripped title = %v, want Intro", p.FrontMatter["title"])
+    }
+    if got := strings.TrimSpace(string(p.Content)); got != "# Hello" {
+        t.Fatalf("round-tripped Content = %q, want %q", got, "# Hello")
+    }
+}
+
+func TestSerializeNoneReturnsContentUnchanged(t *testing.T) {
+    content := []byte("plain body")
+    out, err := Serialize(None, map[string]any{"ignored": true}, content)
+    if err != nil {
+        t.Fatalf("Serialize() error = %v", err)
+    }
+    if !bytes.Equal(out, content) {
+        t.Fatalf("Serialize(None) = %q, want content unchanged %q", out, content)
+    }
+}
+
+func TestDecodeDocumentJSON(t *testing.T) {
+    m, err := DecodeDocument(JSON, []byte(`{"name": "intro", "draft": true}`))
+    if err != nil {
+        t.Fatalf("DecodeDocument() error = %v", err)
+    }
+    if m["name"] != "intro" || m["draft"] != true {
+        t.Fatalf("DecodeDocument() = %v, want name=intro draft=true", m)
+    }
+}
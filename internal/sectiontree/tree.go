@@ -0,0 +1,202 @@
+// Package sectiontree implements a radix tree keyed by canonical,
+// slash-joined paths (e.g. "1", "1/2", "appendix/A/1"), replacing the
+// flat-slice-plus-backward-scan reconstruction convert.buildHierarchy used
+// to do. Lookup, prefix walks, and ancestor chains are O(depth) instead of
+// the O(n^2) string-prefix comparisons a post-hoc scan needs, and the same
+// path scheme extends naturally to per-language variants ("en/1/2"),
+// stable slug regeneration, and cross-section link resolution.
+package sectiontree
+
+import "strings"
+
+// Node is one entry in a Tree. A path segment inserted only to bridge a gap
+// between an ancestor and a deeper descendant (e.g. "1/1" when only "1"
+// and "1/1/1" were ever inserted) carries no value; HasValue reports which
+// is which.
+type Node[V any] struct {
+    path     string
+    value    V
+    hasValue bool
+    parent   *Node[V]
+    children []*Node[V]
+}
+
+// Path returns n's canonical, slash-joined path.
+func (n *Node[V]) Path() string { return n.path }
+
+// Value returns the value inserted at n's path. Its zero value is returned
+// for a placeholder node; check HasValue first.
+func (n *Node[V]) Value() V { return n.value }
+
+// HasValue reports whether a value was inserted at n's path, as opposed to
+// n existing only to bridge a gap between an ancestor and a descendant.
+func (n *Node[V]) HasValue() bool { return n.hasValue }
+
+// Children returns n's immediate children, in insertion order.
+func (n *Node[V]) Children() []*Node[V] { return n.children }
+
+// Tree is a radix tree of Node[V], addressed by canonical, slash-joined
+// paths split from a dotted section number (e.g. "1.2.3" -> "1/2/3").
+type Tree[V any] struct {
+    root *Node[V]
+}
+
+// New returns an empty Tree.
+func New[V any]() *Tree[V] {
+    return &Tree[V]{root: &Node[V]{}}
+}
+
+func splitPath(path string) []string {
+    if path == "" {
+        return nil
+    }
+    return strings.Split(path, "/")
+}
+
+func childNamed[V any](n *Node[V], seg string) *Node[V] {
+    for _, c := range n.children {
+        if lastSegment(c.path) == seg {
+            return c
+        }
+    }
+    return nil
+}
+
+func lastSegment(path string) string {
+    if i := strings.LastIndexByte(path, '/'); i >= 0 {
+        return path[i+1:]
+    }
+    return path
+}
+
+// Insert adds value at path, splitting it on "/" and creating any missing
+// ancestor nodes as valueless placeholders along the way, so a numbering
+// gap (e.g. inserting "1" then "1/1/1" without ever inserting "1/1") still
+// produces a single connected tree instead of an orphaned node. Inserting
+// at a path a second time overwrites its value.
+func (t *Tree[V]) Insert(path string, value V) *Node[V] {
+    cur := t.root
+    var built strings.Builder
+    for i, seg := range splitPath(path) {
+        if i > 0 {
+            built.WriteByte('/')
+        }
+        built.WriteString(seg)
+        next := childNamed(cur, seg)
+        if next == nil {
+            next = &Node[V]{path: built.String(), parent: cur}
+            cur.children = append(cur.children, next)
+        }
+        cur = next
+    }
+    cur.value = value
+    cur.hasValue = true
+    return cur
+}
+
+// Lookup returns the node at path, if one has been inserted or created as
+// a bridging placeholder.
+func (t *Tree[V]) Lookup(path string) (*Node[V], bool) {
+    cur := t.root
+    for _, seg := range splitPath(path) {
+        next := childNamed(cur, seg)
+        if next == nil {
+            return nil, false
+        }
+        cur = next
+    }
+    return cur, true
+}
+
+// Roots returns the tree's top-level nodes.
+func (t *Tree[V]) Roots() []*Node[V] { return t.root.children }
+
+// WalkFunc is called with each visited node's path and value during a
+// Walk or WalkPrefix. Returning false stops the walk early.
+type WalkFunc[V any] func(path string, value V) bool
+
+// Walk visits every node in the tree in depth-first, pre-order, calling fn
+// for each node that carries a value (bridging placeholders are descended
+// into but not themselves visited).
+func (t *Tree[V]) Walk(fn WalkFunc[V]) {
+    walk(t.root, fn)
+}
+
+// WalkPrefix visits, in the same order as Walk, every valued node at or
+// beneath prefix. A prefix that names a bridging placeholder (or nothing
+// at all) still walks whatever valued descendants exist beneath it.
+func (t *Tree[V]) WalkPrefix(prefix string, fn WalkFunc[V]) {
+    n, ok := t.Lookup(prefix)
+    if !ok {
+        return
+    }
+    walk(n, fn)
+}
+
+func walk[V any](n *Node[V], fn WalkFunc[V]) bool {
+    if n.hasValue {
+        if !fn(n.path, n.value) {
+            return false
+        }
+    }
+    for _, c := range n.children {
+        if !walk(c, fn) {
+            return false
+        }
+    }
+    return true
+}
+
+// Parent returns the nearest ancestor of path that carries a value,
+// skipping over any bridging placeholders a numbering gap created.
+func (t *Tree[V]) Parent(path string) (*Node[V], bool) {
+    n, ok := t.Lookup(path)
+    if !ok {
+        return nil, false
+    }
+    for p := n.parent; p != nil; p = p.parent {
+        if p.hasValue {
+            return p, true
+        }
+    }
+    return nil, false
+}
+
+// Ancestors returns path's valued ancestors, root-most first. It never
+// includes placeholder nodes a numbering gap bridged.
+func (t *Tree[V]) Ancestors(path string) []*Node[V] {
+    n, ok := t.Lookup(path)
+    if !ok {
+        return nil
+    }
+    var out []*Node[V]
+    for p := n.parent; p != nil; p = p.parent {
+        if p.hasValue {
+            out = append(out, p)
+        }
+    }
+    for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+        out[i], out[j] = out[j], out[i]
+    }
+    return out
+}
+
+// Prune removes every node for which match returns true, along with its
+// entire subtree, detaching it from its parent. This is a single O(n) walk,
+// replacing a per-entry filter pass when the removal criterion (e.g. a
+// maximum Number depth) is most naturally expressed against the tree.
+func (t *Tree[V]) Prune(match func(*Node[V]) bool) {
+    pruneChildren(t.root, match)
+}
+
+func pruneChildren[V any](n *Node[V], match func(*Node[V]) bool) {
+    kept := n.children[:0]
+    for _, c := range n.children {
+        if match(c) {
+            continue
+        }
+        pruneChildren(c, match)
+        kept = append(kept, c)
+    }
+    n.children = kept
+}
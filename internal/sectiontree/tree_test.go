@@ -0,0 +1,80 @@
+package sectiontree
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestInsertSkippedDepth(t *testing.T) {
+    tree := New[string]()
+    tree.Insert("1", "Intro")
+    tree.Insert("1/1/1", "Deeply nested")
+
+    var walked []string
+    tree.Walk(func(path string, v string) bool {
+        walked = append(walked, path)
+        return true
+    })
+    if want := []string{"1", "1/1/1"}; !reflect.DeepEqual(walked, want) {
+        t.Fatalf("Walk() = %v, want %v", walked, want)
+    }
+
+    parent, ok := tree.Parent("1/1/1")
+    if !ok || parent.Path() != "1" {
+        t.Fatalf("Parent(%q) = %v, %v, want node %q", "1/1/1", parent, ok, "1")
+    }
+}
+
+func TestMixedNumberingRoots(t *testing.T) {
+    tree := New[string]()
+    tree.Insert("I", "Roman")
+    tree.Insert("A/1", "Alpha")
+    tree.Insert("1/2", "Numeric")
+
+    for _, path := range []string{"I", "A/1", "1/2"} {
+        if _, ok := tree.Parent(path); ok {
+            t.Errorf("Parent(%q) found an ancestor, want none", path)
+        }
+    }
+
+    roots := tree.Roots()
+    if len(roots) != 3 {
+        t.Fatalf("Roots() = %d nodes, want 3", len(roots))
+    }
+}
+
+func TestAppendixRoot(t *testing.T) {
+    tree := New[string]()
+    tree.Insert("A/1", "Top-level part A, section 1")
+    tree.Insert("appendix/A/1", "Appendix A, section 1")
+
+    if _, ok := tree.Parent("appendix/A/1"); ok {
+        t.Fatalf("Parent(%q) found an ancestor, want none", "appendix/A/1")
+    }
+
+    node, ok := tree.Lookup("A/1")
+    if !ok || !node.HasValue() {
+        t.Fatalf("Lookup(%q) = %v, %v, want the top-level section unaffected by the appendix path", "A/1", node, ok)
+    }
+}
+
+func TestPrune(t *testing.T) {
+    tree := New[int]()
+    tree.Insert("1", 1)
+    tree.Insert("1/1", 2)
+    tree.Insert("1/1/1", 3)
+    tree.Insert("2", 1)
+
+    tree.Prune(func(n *Node[int]) bool {
+        return n.HasValue() && n.Value() > 1
+    })
+
+    var walked []string
+    tree.Walk(func(path string, v int) bool {
+        walked = append(walked, path)
+        return true
+    })
+    if want := []string{"1", "2"}; !reflect.DeepEqual(walked, want) {
+        t.Fatalf("Walk() after Prune = %v, want %v", walked, want)
+    }
+}